@@ -0,0 +1,291 @@
+// Package alerts evaluates line-movement alert rules against odds changes and delivers the
+// matches to users' webhooks, signed with each rule's secret and retried on failure.
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+	"sports_api/internal/stream"
+)
+
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>"
+	SignatureHeader = "X-Alert-Signature"
+	// TimestampHeader carries the Unix time the payload was signed at
+	TimestampHeader = "X-Alert-Timestamp"
+
+	maxAttempts  = 3
+	queueSize    = 256
+	workers      = 4
+	rulesMaxAge  = 30 * time.Second
+	requestLimit = 10 * time.Second
+)
+
+// RuleSource returns every user's alert rules
+type RuleSource func() ([]models.AlertRule, error)
+
+// DeliveryLog records the outcome of a delivery
+type DeliveryLog func(models.AlertDelivery) error
+
+// Payload is the JSON body posted to a webhook
+type Payload struct {
+	RuleID   string            `json:"rule_id"`
+	UserID   string            `json:"user_id"`
+	Movement float64           `json:"movement"`
+	Unit     string            `json:"unit"`
+	Change   models.OddsChange `json:"change"`
+}
+
+// Movement is how far a change moved in a unit: line points, or over price cents. It reports
+// false for changes that aren't moves of an existing line.
+func Movement(change models.OddsChange, unit string) (float64, bool) {
+	if change.Type != "move" {
+		return 0, false
+	}
+	switch unit {
+	case "points":
+		return float64(change.Line - change.PreviousLine), true
+	case "cents":
+		return float64(americanCents(change.Over) - americanCents(change.PreviousOver)), true
+	default:
+		return 0, false
+	}
+}
+
+// americanCents maps American odds onto a continuous scale where -105 to +105 is a 10 cent move
+func americanCents(odds int) int {
+	if odds < 0 {
+		return odds + 100
+	}
+	return odds - 100
+}
+
+// Matches reports whether a change triggers a rule, and by how much it moved
+func Matches(rule models.AlertRule, change models.OddsChange) (float64, bool) {
+	if rule.Sport != "" && !strings.EqualFold(rule.Sport, change.Sport) {
+		return 0, false
+	}
+	if rule.Player != "" && identity.Canonical(rule.Player) != identity.Canonical(change.Player) {
+		return 0, false
+	}
+	if rule.Market != "" && !strings.EqualFold(rule.Market, change.Market) {
+		return 0, false
+	}
+	if rule.Sportbook != "" && !strings.EqualFold(rule.Sportbook, change.Sportbook) {
+		return 0, false
+	}
+
+	movement, ok := Movement(change, rule.ThresholdUnit)
+	if !ok || movement == 0 {
+		return 0, false
+	}
+	switch {
+	case rule.Direction == "up" && movement < 0, rule.Direction == "down" && movement > 0:
+		return 0, false
+	case movement < rule.Threshold && -movement < rule.Threshold:
+		return 0, false
+	}
+	return movement, true
+}
+
+// Sign returns the signature header value for a body signed at timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type delivery struct {
+	rule    models.AlertRule
+	payload Payload
+}
+
+// Evaluator matches odds changes against alert rules and delivers the matches through a pool
+// of workers. Rules are cached for rulesMaxAge or until Invalidate is called.
+type Evaluator struct {
+	rules  RuleSource
+	log    DeliveryLog
+	client *http.Client
+
+	// backoff is the wait before each retry; tests shorten it
+	backoff []time.Duration
+	queue   chan delivery
+
+	mu       sync.Mutex
+	cached   []models.AlertRule
+	cachedAt time.Time
+}
+
+// NewEvaluator creates an evaluator and starts its delivery workers
+func NewEvaluator(rules RuleSource, log DeliveryLog, client *http.Client) *Evaluator {
+	e := &Evaluator{
+		rules:   rules,
+		log:     log,
+		client:  client,
+		backoff: []time.Duration{time.Second, 5 * time.Second},
+		queue:   make(chan delivery, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+	return e
+}
+
+// Invalidate drops the cached rules so the next change reloads them
+func (e *Evaluator) Invalidate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cached = nil
+}
+
+func (e *Evaluator) currentRules() ([]models.AlertRule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cached != nil && time.Since(e.cachedAt) < rulesMaxAge {
+		return e.cached, nil
+	}
+	rules, err := e.rules()
+	if err != nil {
+		return nil, err
+	}
+	e.cached, e.cachedAt = rules, time.Now()
+	return rules, nil
+}
+
+// Run evaluates every change the hub publishes, resubscribing from the last seen change if the
+// hub drops it for falling behind. It returns when ctx is done.
+func (e *Evaluator) Run(ctx context.Context, hub *stream.OddsHub) {
+	var lastID string
+	for ctx.Err() == nil {
+		replay, _, changes, cancel := hub.Subscribe(stream.OddsFilter{}, lastID)
+		for _, change := range replay {
+			e.Evaluate(change)
+			lastID = hub.EventID(change)
+		}
+	receive:
+		for {
+			select {
+			case <-ctx.Done():
+				break receive
+			case change, ok := <-changes:
+				if !ok {
+					break receive
+				}
+				e.Evaluate(change)
+				lastID = hub.EventID(change)
+			}
+		}
+		cancel()
+	}
+}
+
+// Evaluate queues a delivery for every rule the change triggers
+func (e *Evaluator) Evaluate(change models.OddsChange) {
+	rules, err := e.currentRules()
+	if err != nil {
+		log.Printf("alerts: failed to load rules: %v", err)
+		return
+	}
+	for _, rule := range rules {
+		movement, ok := Matches(rule, change)
+		if !ok {
+			continue
+		}
+		d := delivery{rule: rule, payload: Payload{
+			RuleID:   rule.ID,
+			UserID:   rule.UserID,
+			Movement: movement,
+			Unit:     rule.ThresholdUnit,
+			Change:   change,
+		}}
+		select {
+		case e.queue <- d:
+		default:
+			e.record(d, nil, 0, 0, fmt.Errorf("delivery queue full"))
+		}
+	}
+}
+
+func (e *Evaluator) work() {
+	for d := range e.queue {
+		e.deliver(d)
+	}
+}
+
+// deliver posts a payload, retrying network errors, 429s and 5xxs up to maxAttempts times
+func (e *Evaluator) deliver(d delivery) {
+	body, err := json.Marshal(d.payload)
+	if err != nil {
+		e.record(d, body, 0, 0, err)
+		return
+	}
+
+	var status int
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		status, err = e.post(d.rule, body)
+		retry := err != nil || status == http.StatusTooManyRequests || status >= 500
+		if !retry || attempt == maxAttempts {
+			e.record(d, body, attempt, status, err)
+			return
+		}
+		time.Sleep(e.backoff[min(attempt-1, len(e.backoff)-1)])
+	}
+}
+
+func (e *Evaluator) post(rule models.AlertRule, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestLimit)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rule.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(rule.Secret, timestamp, body))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (e *Evaluator) record(d delivery, body []byte, attempts, status int, err error) {
+	entry := models.AlertDelivery{
+		RuleID:      d.rule.ID,
+		UserID:      d.rule.UserID,
+		ChangeID:    d.payload.Change.ID,
+		WebhookURL:  d.rule.WebhookURL,
+		Attempts:    attempts,
+		StatusCode:  status,
+		Success:     err == nil && status >= 200 && status < 300,
+		Payload:     string(body),
+		DeliveredAt: time.Now(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else if !entry.Success {
+		entry.Error = "webhook responded " + http.StatusText(status)
+	}
+	if logErr := e.log(entry); logErr != nil {
+		log.Printf("alerts: %v", logErr)
+	}
+}
@@ -0,0 +1,150 @@
+package alerts
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func move(line, prevLine float32, over, prevOver int) models.OddsChange {
+	return models.OddsChange{
+		ID: 7, Type: "move", Sport: "nba", Player: "Luka Dončić", Market: "points", Sportbook: "FanDuel",
+		Line: line, PreviousLine: prevLine, Over: over, PreviousOver: prevOver,
+	}
+}
+
+func TestMovement(t *testing.T) {
+	points, ok := Movement(move(29.5, 28.5, -110, -110), "points")
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, points)
+
+	// -105 to +105 crosses even money: 10 cents, not 210
+	cents, ok := Movement(move(28.5, 28.5, 105, -105), "cents")
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, cents)
+
+	cents, _ = Movement(move(28.5, 28.5, -130, -110), "cents")
+	assert.Equal(t, -20.0, cents)
+
+	_, ok = Movement(models.OddsChange{Type: "open"}, "points")
+	assert.False(t, ok)
+}
+
+func TestMatches(t *testing.T) {
+	rule := models.AlertRule{Player: "Luka Doncic", Market: "POINTS", Threshold: 1, ThresholdUnit: "points", Direction: "any"}
+
+	_, ok := Matches(rule, move(29.5, 28.5, -110, -110))
+	assert.True(t, ok, "accents and case are ignored")
+
+	_, ok = Matches(rule, move(29, 28.5, -110, -110))
+	assert.False(t, ok, "below threshold")
+
+	rule.Direction = "up"
+	_, ok = Matches(rule, move(27.5, 28.5, -110, -110))
+	assert.False(t, ok, "wrong direction")
+
+	rule.Direction = "down"
+	movement, ok := Matches(rule, move(27.5, 28.5, -110, -110))
+	assert.True(t, ok)
+	assert.Equal(t, -1.0, movement)
+
+	rule.Sportbook = "DraftKings"
+	_, ok = Matches(rule, move(27.5, 28.5, -110, -110))
+	assert.False(t, ok, "other book")
+}
+
+func TestDeliverRetriesAndSigns(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		assert.Equal(t, Sign("s3cret", timestamp, body), r.Header.Get(SignatureHeader))
+
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	logged := make(chan models.AlertDelivery, 1)
+	rule := models.AlertRule{ID: "r1", UserID: "u1", Threshold: 1, ThresholdUnit: "points", Direction: "any", WebhookURL: server.URL, Secret: "s3cret"}
+	e := NewEvaluator(
+		func() ([]models.AlertRule, error) { return []models.AlertRule{rule}, nil },
+		func(d models.AlertDelivery) error { logged <- d; return nil },
+		server.Client(),
+	)
+	e.backoff = []time.Duration{time.Millisecond}
+
+	e.Evaluate(move(29.5, 28.5, -110, -110))
+
+	d := <-logged
+	assert.True(t, d.Success)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Equal(t, http.StatusNoContent, d.StatusCode)
+	assert.Equal(t, int64(7), d.ChangeID)
+	assert.Contains(t, d.Payload, `"movement":1`)
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	logged := make(chan models.AlertDelivery, 1)
+	rule := models.AlertRule{ID: "r1", UserID: "u1", Threshold: 1, ThresholdUnit: "points", Direction: "any", WebhookURL: server.URL}
+	e := NewEvaluator(
+		func() ([]models.AlertRule, error) { return []models.AlertRule{rule}, nil },
+		func(d models.AlertDelivery) error { logged <- d; return nil },
+		server.Client(),
+	)
+
+	e.Evaluate(move(29.5, 28.5, -110, -110))
+
+	d := <-logged
+	assert.False(t, d.Success)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, "webhook responded Gone", d.Error)
+}
+
+func TestPublicAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"100.100.100.200", "0.0.0.0", "::1", "fe80::1", "fd00:ec2::254", "::ffff:127.0.0.1", "224.0.0.1"} {
+		assert.False(t, PublicAddress(netip.MustParseAddr(addr)), addr)
+	}
+	for _, addr := range []string{"93.184.216.34", "8.8.8.8", "2606:4700::1111"} {
+		assert.True(t, PublicAddress(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	// the test server listens on loopback, as would a hostname rebound to it
+	_, err := NewWebhookClient(time.Second).Post(server.URL, "application/json", strings.NewReader("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not public")
+	assert.Equal(t, int32(0), hits.Load())
+}
@@ -0,0 +1,167 @@
+// Package backtest replays historical games, projecting each one from the games before it, and
+// scores the projections against the actual box scores and the prop line of the day.
+package backtest
+
+import (
+	"math"
+
+	"sports_api/internal/betting"
+	"sports_api/internal/models"
+	"sports_api/internal/projection"
+)
+
+const calibrationBins = 10
+
+// Options select the games to score. Games before From only serve as history, and players need
+// MinGames earlier games before they're projected. Distribution turns projections into over
+// probabilities, defaulting to poisson.
+type Options struct {
+	Market       string
+	Distribution string
+	From         string
+	To           string
+	MinGames     int
+}
+
+// Run projects every game between From and To from the same player's earlier games and reports
+// the model's error, calibration and simulated betting results. stats must be ordered by player
+// and date; lines are matched to games by player ID and tip-off, so a game needs a StartTime to
+// be bet.
+func Run(stats []models.NBAPlayerGameStat, lines []models.PropLine, projector projection.Projector, opts Options) models.BacktestReport {
+	if opts.Distribution == "" {
+		opts.Distribution = projection.DefaultDistribution
+	}
+	report := models.BacktestReport{
+		Market:       opts.Market,
+		Model:        projector.Info().Name,
+		Distribution: opts.Distribution,
+		From:         opts.From,
+		To:           opts.To,
+	}
+
+	games := make([]projection.Game, len(stats))
+	for i, s := range stats {
+		games[i] = projection.Game{Date: s.GameDate, Opponent: s.Opponent, Value: s.Value, Minutes: s.Minutes}
+	}
+	var opponentFactors []float64
+	if projection.Uses(projector, "opponent") {
+		opponentFactors = projection.OpponentFactors(games, projection.OpponentMinGames)
+	}
+
+	type lineKey struct {
+		playerID string
+		tip      int64
+	}
+	lineByGame := make(map[lineKey]models.PropLine, len(lines))
+	for _, line := range lines {
+		if line.PlayerID != "" && !line.StartTime.IsZero() {
+			lineByGame[lineKey{line.PlayerID, line.StartTime.Unix()}] = line
+		}
+	}
+
+	var bins [calibrationBins]struct {
+		count, hits int
+		predicted   float64
+	}
+	absError, signedError := 0.0, 0.0
+
+	start := 0
+	for i, game := range stats {
+		if i > 0 && stats[i-1].PlayerID != game.PlayerID {
+			start = i
+		}
+		if game.GameDate < opts.From || game.GameDate > opts.To {
+			continue
+		}
+		// only games on earlier dates were known before tip-off, so expected minutes stay unknown
+		in := projection.Input{History: games[start:i]}
+		if len(in.History) < opts.MinGames {
+			continue
+		}
+		if opponentFactors != nil {
+			in.OpponentFactor = opponentFactors[i]
+		}
+		projected, ok := projector.Project(in)
+		if !ok {
+			continue
+		}
+
+		report.Games++
+		absError += math.Abs(projected - game.Value)
+		signedError += projected - game.Value
+
+		if game.StartTime.IsZero() {
+			continue
+		}
+		line, ok := lineByGame[lineKey{game.PlayerID, game.StartTime.Unix()}]
+		if !ok {
+			continue
+		}
+		report.LinesMatched++
+
+		sample := make([]float64, len(in.History))
+		for j, g := range in.History {
+			sample[j] = g.Value
+		}
+		over, under, _, err := projection.OverUnder(opts.Distribution, projected, sample, line.Line)
+		if err != nil {
+			continue
+		}
+		result := betting.Result("over", line.Line, game.Value)
+		if result != "push" {
+			bin := min(int(over*calibrationBins), calibrationBins-1)
+			bins[bin].count++
+			bins[bin].predicted += over
+			if result == "won" {
+				bins[bin].hits++
+			}
+		}
+
+		side, price := "", 0
+		switch {
+		case over > betting.ImpliedProbability(line.Over):
+			side, price = "over", line.Over
+		case under > betting.ImpliedProbability(line.Under):
+			side, price = "under", line.Under
+		default:
+			continue
+		}
+		report.Bets++
+		result = betting.Result(side, line.Line, game.Value)
+		switch result {
+		case "won":
+			report.Wins++
+		case "lost":
+			report.Losses++
+		case "push":
+			report.Pushes++
+		}
+		report.Units += betting.Profit(result, 1, price)
+	}
+
+	if report.Games > 0 {
+		report.MAE = absError / float64(report.Games)
+		report.Bias = signedError / float64(report.Games)
+	}
+	if settled := report.Wins + report.Losses; settled > 0 {
+		report.HitRate = float64(report.Wins) / float64(settled)
+	}
+	if report.Bets > 0 {
+		report.ROI = report.Units / float64(report.Bets)
+	}
+
+	report.Calibration = []models.CalibrationBin{}
+	for i, bin := range bins {
+		if bin.count == 0 {
+			continue
+		}
+		report.Calibration = append(report.Calibration, models.CalibrationBin{
+			Lower:         float64(i) / calibrationBins,
+			Upper:         float64(i+1) / calibrationBins,
+			Count:         bin.count,
+			MeanPredicted: bin.predicted / float64(bin.count),
+			ObservedRate:  float64(bin.hits) / float64(bin.count),
+		})
+	}
+	return report
+}
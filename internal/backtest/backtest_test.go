@@ -0,0 +1,157 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"sports_api/internal/models"
+	"sports_api/internal/projection"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tip is a 7:30pm ET tip-off on date
+func tip(date string) time.Time {
+	day, _ := time.Parse(time.DateOnly, date)
+	return day.Add(24*time.Hour + 30*time.Minute)
+}
+
+func stat(playerID, date string, value float64) models.NBAPlayerGameStat {
+	return models.NBAPlayerGameStat{PlayerID: playerID, GameID: playerID + date, GameDate: date, Value: value, Minutes: 30, StartTime: tip(date)}
+}
+
+func TestRun(t *testing.T) {
+	stats := []models.NBAPlayerGameStat{
+		stat("1", "2024-01-01", 10),
+		stat("1", "2024-01-02", 20),
+		stat("1", "2024-01-03", 30),
+		stat("1", "2024-01-04", 40),
+		stat("1", "2024-01-05", 50),
+		stat("2", "2024-01-03", 12),
+		stat("2", "2024-01-04", 14),
+	}
+	lines := []models.PropLine{
+		{Player: "One", PlayerID: "1", Date: "2024-01-04", StartTime: tip("2024-01-04"), Line: 30.5, Over: -110, Under: -110},
+		{Player: "One", PlayerID: "1", Date: "2024-01-05", StartTime: tip("2024-01-05"), Line: 50, Over: -110, Under: -110},
+		{Player: "Unknown", Date: "2024-01-04", StartTime: tip("2024-01-04"), Line: 10.5, Over: -110, Under: -110},
+	}
+	opts := Options{Market: "player_points", From: "2024-01-04", To: "2024-01-05", MinGames: 2}
+
+	projector, ok := projection.Lookup("rolling_average")
+	require.True(t, ok)
+	report := Run(stats, lines, projector, opts)
+
+	assert.Equal(t, "player_points", report.Market)
+	assert.Equal(t, "rolling_average", report.Model)
+	assert.Equal(t, "poisson", report.Distribution)
+	// player 2 has a single earlier game, short of MinGames; player 1 projects 20 and 25
+	assert.Equal(t, 2, report.Games)
+	assert.InDelta(t, 22.5, report.MAE, 1e-9)
+	assert.InDelta(t, -22.5, report.Bias, 1e-9)
+	assert.Equal(t, 2, report.LinesMatched)
+
+	// both projections sit well under the line, so both bet the under: a loss at 40 and a push at 50
+	assert.Equal(t, 2, report.Bets)
+	assert.Equal(t, 0, report.Wins)
+	assert.Equal(t, 1, report.Losses)
+	assert.Equal(t, 1, report.Pushes)
+	assert.Equal(t, 0.0, report.HitRate)
+	assert.InDelta(t, -1, report.Units, 1e-9)
+	assert.InDelta(t, -0.5, report.ROI, 1e-9)
+
+	// the push is left out of calibration
+	require.Len(t, report.Calibration, 1)
+	bin := report.Calibration[0]
+	assert.Equal(t, 1, bin.Count)
+	assert.LessOrEqual(t, bin.Lower, bin.MeanPredicted)
+	assert.Less(t, bin.MeanPredicted, bin.Upper)
+	assert.Equal(t, 1.0, bin.ObservedRate)
+}
+
+func TestRunMatchesLinesByTipOff(t *testing.T) {
+	stats := []models.NBAPlayerGameStat{stat("1", "2024-01-01", 10), stat("1", "2024-01-02", 20)}
+	lines := []models.PropLine{
+		// the snapshot before an earlier tip the same night, and one for a game without a tip
+		{PlayerID: "1", Date: "2024-01-02", StartTime: tip("2024-01-02").Add(-90 * time.Minute), Line: 30.5, Over: -110, Under: -110},
+		{PlayerID: "1", Date: "2024-01-02", StartTime: tip("2024-01-02"), Line: 12.5, Over: -110, Under: -110},
+	}
+	projector, _ := projection.Lookup("rolling_average")
+	opts := Options{From: "2024-01-02", To: "2024-01-02", MinGames: 1}
+
+	report := Run(stats, lines, projector, opts)
+	require.Equal(t, 1, report.LinesMatched)
+	// projecting 10 against 12.5 bets the under, which loses at 20
+	assert.Equal(t, 1, report.Losses)
+
+	stats[1].StartTime = time.Time{}
+	assert.Equal(t, 0, Run(stats, lines, projector, opts).LinesMatched)
+}
+
+func TestRunDistribution(t *testing.T) {
+	stats := []models.NBAPlayerGameStat{
+		stat("1", "2024-01-01", 2),
+		stat("1", "2024-01-02", 30),
+		stat("1", "2024-01-03", 20),
+	}
+	lines := []models.PropLine{{PlayerID: "1", Date: "2024-01-03", StartTime: tip("2024-01-03"), Line: 15.5, Over: -110, Under: -110}}
+	opts := Options{From: "2024-01-03", To: "2024-01-03", MinGames: 1}
+	projector, _ := projection.Lookup("rolling_average")
+
+	poisson := Run(stats, lines, projector, opts)
+	opts.Distribution = "negative_binomial"
+	negBinomial := Run(stats, lines, projector, opts)
+
+	assert.Equal(t, "negative_binomial", negBinomial.Distribution)
+	require.Len(t, poisson.Calibration, 1)
+	require.Len(t, negBinomial.Calibration, 1)
+	// the game log's wide spread pulls the over probability toward a coin flip
+	assert.Less(t, negBinomial.Calibration[0].MeanPredicted, poisson.Calibration[0].MeanPredicted)
+
+	// the empirical distribution needs no more than the one earlier game
+	opts.Distribution = "empirical"
+	assert.Equal(t, 1, Run(stats, lines, projector, opts).LinesMatched)
+	// negative binomial needs two
+	opts.Distribution, opts.From = "negative_binomial", "2024-01-02"
+	assert.Equal(t, 0, Run(stats[:2], []models.PropLine{{PlayerID: "1", Date: "2024-01-02", StartTime: tip("2024-01-02"), Line: 15.5}}, projector, opts).Bets)
+}
+
+func TestRunUsesOnlyEarlierGames(t *testing.T) {
+	stats := []models.NBAPlayerGameStat{
+		stat("1", "2024-01-01", 10),
+		stat("1", "2024-01-02", 20),
+		stat("1", "2024-01-03", 30),
+		stat("2", "2024-01-02", 5),
+		stat("2", "2024-01-03", 7),
+	}
+
+	projector := &recordingProjector{}
+	report := Run(stats, nil, projector, Options{From: "2024-01-02", To: "2024-01-03", MinGames: 1})
+
+	assert.Equal(t, "recording", report.Model)
+	assert.Equal(t, 3, report.Games)
+	require.Len(t, projector.seen, 3)
+	assert.Equal(t, []string{"2024-01-01"}, projector.seen[0])
+	assert.Equal(t, []string{"2024-01-01", "2024-01-02"}, projector.seen[1])
+	assert.Equal(t, []string{"2024-01-02"}, projector.seen[2])
+	assert.Empty(t, report.Calibration)
+	assert.Equal(t, 0, report.Bets)
+}
+
+// recordingProjector records the dates of the history it's given
+type recordingProjector struct {
+	seen [][]string
+}
+
+func (p *recordingProjector) Info() models.ProjectionModel {
+	return models.ProjectionModel{Name: "recording"}
+}
+
+func (p *recordingProjector) Project(in projection.Input) (float64, bool) {
+	dates := []string{}
+	for _, g := range in.History {
+		dates = append(dates, g.Date)
+	}
+	p.seen = append(p.seen, dates)
+	return 0, true
+}
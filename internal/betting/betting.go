@@ -0,0 +1,189 @@
+// Package betting grades prop bets and summarizes a bettor's record, ROI and closing line value.
+package betting
+
+import (
+	"math"
+	"time"
+
+	"sports_api/internal/models"
+)
+
+// DecimalOdds converts American odds to decimal odds (total return per unit staked)
+func DecimalOdds(price int) float64 {
+	if price > 0 {
+		return 1 + float64(price)/100
+	}
+	return 1 + 100/float64(-price)
+}
+
+// ImpliedProbability is the break-even win probability of American odds
+func ImpliedProbability(price int) float64 {
+	return 1 / DecimalOdds(price)
+}
+
+// Result settles a side against the actual stat: "won", "lost" or "push"
+func Result(side string, line, actual float64) string {
+	switch {
+	case actual == line:
+		return "push"
+	case (actual > line) == (side == "over"):
+		return "won"
+	default:
+		return "lost"
+	}
+}
+
+// Profit is what a settled bet won or lost
+func Profit(result string, stake float64, price int) float64 {
+	switch result {
+	case "won":
+		return stake * (DecimalOdds(price) - 1)
+	case "lost":
+		return -stake
+	default:
+		return 0
+	}
+}
+
+// Grade settles a bet on its outcome and records its closing snapshot, when one was found
+func Grade(bet models.Bet, outcome models.BetOutcome, closing *models.Odds, now time.Time) models.Bet {
+	actual := outcome.Value
+	bet.GameID = outcome.GameID
+	bet.Actual = &actual
+	bet.Status = Result(bet.Side, bet.Line, actual)
+	bet.Profit = Profit(bet.Status, bet.Stake, bet.Price)
+	bet.GradedAt = &now
+
+	if closing != nil {
+		line := float64(closing.Line)
+		price := closing.Over
+		if bet.Side == "under" {
+			price = closing.Under
+		}
+		bet.ClosingLine, bet.ClosingPrice = &line, &price
+	}
+	return bet
+}
+
+// Void settles a bet whose player had no game on the bet's game date, refunding the stake
+func Void(bet models.Bet, now time.Time) models.Bet {
+	bet.Status = "void"
+	bet.Actual = nil
+	bet.Profit = 0
+	bet.GradedAt = &now
+	return bet
+}
+
+// CLV fills in how the bet compares to the closing snapshot. Line CLV is in points toward the
+// bet's side (an over at 24.5 closing 25.5 is +1); probability CLV is the closing price's
+// implied probability minus the bet price's, so positive means the bet got a better price.
+func CLV(bet models.Bet) models.Bet {
+	if bet.ClosingLine != nil {
+		line := *bet.ClosingLine - bet.Line
+		if bet.Side == "under" {
+			line = -line
+		}
+		bet.CLVLine = &line
+	}
+	if bet.ClosingPrice != nil && *bet.ClosingPrice != 0 {
+		prob := ImpliedProbability(*bet.ClosingPrice) - ImpliedProbability(bet.Price)
+		bet.CLVProb = &prob
+	}
+	return bet
+}
+
+// Summarize totals bets overall and by market and sportsbook. Bets should already have CLV filled in.
+func Summarize(bets []models.Bet) models.BetSummary {
+	summary := models.BetSummary{
+		ByMarket: map[string]models.BetRecord{},
+		ByBook:   map[string]models.BetRecord{},
+	}
+
+	type totals struct {
+		record        models.BetRecord
+		clvLine       float64
+		clvProb       float64
+		clvProbBets   int
+		beatCloseBets int
+	}
+	overall := &totals{}
+	byMarket := map[string]*totals{}
+	byBook := map[string]*totals{}
+
+	for _, bet := range bets {
+		if byMarket[bet.Market] == nil {
+			byMarket[bet.Market] = &totals{}
+		}
+		if byBook[bet.Sportbook] == nil {
+			byBook[bet.Sportbook] = &totals{}
+		}
+		for _, t := range []*totals{overall, byMarket[bet.Market], byBook[bet.Sportbook]} {
+			t.record.Bets++
+			switch bet.Status {
+			case "won":
+				t.record.Wins++
+			case "lost":
+				t.record.Losses++
+			case "push":
+				t.record.Pushes++
+			case "void":
+				t.record.Voids++
+				continue
+			default:
+				t.record.Pending++
+				continue
+			}
+			t.record.Staked += bet.Stake
+			t.record.Profit += bet.Profit
+
+			if bet.CLVLine != nil {
+				t.record.CLVBets++
+				t.clvLine += *bet.CLVLine
+				beat := *bet.CLVLine > 0
+				if bet.CLVProb != nil {
+					t.clvProb += *bet.CLVProb
+					t.clvProbBets++
+					beat = beat || (*bet.CLVLine == 0 && *bet.CLVProb > 0)
+				}
+				if beat {
+					t.beatCloseBets++
+				}
+			}
+		}
+	}
+
+	finish := func(t *totals) models.BetRecord {
+		r := t.record
+		if r.Staked > 0 {
+			r.ROI = r.Profit / r.Staked
+		}
+		if r.CLVBets > 0 {
+			r.AvgCLVLine = t.clvLine / float64(r.CLVBets)
+			r.BeatClose = float64(t.beatCloseBets) / float64(r.CLVBets)
+		}
+		if t.clvProbBets > 0 {
+			r.AvgCLVProb = t.clvProb / float64(t.clvProbBets)
+		}
+		return r
+	}
+	summary.Overall = finish(overall)
+	for market, t := range byMarket {
+		summary.ByMarket[market] = finish(t)
+	}
+	for book, t := range byBook {
+		summary.ByBook[book] = finish(t)
+	}
+	return summary
+}
+
+// AmericanOdds is the fair American price for a win probability, 0 when it's 0 or 1
+func AmericanOdds(prob float64) int {
+	switch {
+	case prob <= 0 || prob >= 1:
+		return 0
+	case prob >= 0.5:
+		return int(math.Round(-100 * prob / (1 - prob)))
+	default:
+		return int(math.Round(100 * (1 - prob) / prob))
+	}
+}
@@ -0,0 +1,95 @@
+package betting
+
+import (
+	"testing"
+	"time"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOddsConversions(t *testing.T) {
+	assert.InDelta(t, 2.5, DecimalOdds(150), 1e-9)
+	assert.InDelta(t, 1.909090, DecimalOdds(-110), 1e-6)
+	assert.InDelta(t, 0.5238095, ImpliedProbability(-110), 1e-6)
+	assert.InDelta(t, 0.4, ImpliedProbability(150), 1e-9)
+
+	assert.Equal(t, 150, AmericanOdds(0.4))
+	assert.Equal(t, -300, AmericanOdds(0.75))
+	assert.Equal(t, -100, AmericanOdds(0.5))
+	assert.Equal(t, 0, AmericanOdds(0))
+}
+
+func TestResultAndProfit(t *testing.T) {
+	assert.Equal(t, "won", Result("over", 24.5, 27))
+	assert.Equal(t, "lost", Result("under", 24.5, 27))
+	assert.Equal(t, "push", Result("over", 25, 25))
+	assert.Equal(t, "won", Result("under", 25, 24))
+
+	assert.InDelta(t, 9.090909, Profit("won", 10, -110), 1e-6)
+	assert.Equal(t, 15.0, Profit("won", 10, 150))
+	assert.Equal(t, -10.0, Profit("lost", 10, -110))
+	assert.Equal(t, 0.0, Profit("push", 10, -110))
+}
+
+func TestGradeAndCLV(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	bet := models.Bet{Side: "under", Line: 25.5, Price: -105, Stake: 20, Status: "pending"}
+	closing := &models.Odds{Line: 24.5, Over: -130, Under: 110}
+
+	graded := CLV(Grade(bet, models.BetOutcome{GameID: "001", Value: 22}, closing, now))
+	assert.Equal(t, "won", graded.Status)
+	assert.Equal(t, "001", graded.GameID)
+	assert.InDelta(t, 19.047619, graded.Profit, 1e-6)
+	assert.Equal(t, 110, *graded.ClosingPrice)
+	// the under closed a point lower, so taking 25.5 beat the close
+	assert.Equal(t, 1.0, *graded.CLVLine)
+	assert.InDelta(t, ImpliedProbability(110)-ImpliedProbability(-105), *graded.CLVProb, 1e-9)
+
+	ungraded := CLV(Grade(bet, models.BetOutcome{Value: 30}, nil, now))
+	assert.Equal(t, "lost", ungraded.Status)
+	assert.Nil(t, ungraded.CLVLine)
+	assert.Nil(t, ungraded.CLVProb)
+
+	voided := Void(bet, now)
+	assert.Equal(t, "void", voided.Status)
+	assert.Equal(t, 0.0, voided.Profit)
+	assert.Nil(t, voided.Actual)
+	assert.Equal(t, now, *voided.GradedAt)
+}
+
+func TestSummarize(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	bets := []models.Bet{
+		{Market: "player_points", Sportbook: "FanDuel", Stake: 10, Status: "won", Profit: 10, CLVLine: f(1), CLVProb: f(0.02)},
+		{Market: "player_points", Sportbook: "DraftKings", Stake: 10, Status: "lost", Profit: -10, CLVLine: f(-0.5), CLVProb: f(-0.01)},
+		{Market: "player_assists", Sportbook: "FanDuel", Stake: 20, Status: "push"},
+		{Market: "player_assists", Sportbook: "FanDuel", Stake: 50, Status: "pending"},
+		{Market: "player_assists", Sportbook: "FanDuel", Stake: 30, Status: "void"},
+	}
+
+	summary := Summarize(bets)
+	overall := summary.Overall
+	assert.Equal(t, 5, overall.Bets)
+	assert.Equal(t, 1, overall.Wins)
+	assert.Equal(t, 1, overall.Losses)
+	assert.Equal(t, 1, overall.Pushes)
+	assert.Equal(t, 1, overall.Pending)
+	assert.Equal(t, 1, overall.Voids)
+	assert.Equal(t, 40.0, overall.Staked)
+	assert.Equal(t, 0.0, overall.ROI)
+	assert.Equal(t, 2, overall.CLVBets)
+	assert.Equal(t, 0.25, overall.AvgCLVLine)
+	assert.InDelta(t, 0.005, overall.AvgCLVProb, 1e-9)
+	assert.Equal(t, 0.5, overall.BeatClose)
+
+	fanduel := summary.ByBook["FanDuel"]
+	assert.Equal(t, 4, fanduel.Bets)
+	assert.Equal(t, 30.0, fanduel.Staked)
+	assert.InDelta(t, 10.0/30, fanduel.ROI, 1e-9)
+
+	assists := summary.ByMarket["player_assists"]
+	assert.Equal(t, 3, assists.Bets)
+	assert.Equal(t, 0, assists.CLVBets)
+}
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"sports_api/internal/models"
+)
+
+// EnsureAlertTables creates the schema and tables holding alert rules and their delivery log
+func EnsureAlertTables(db *sql.DB) error {
+	statements := []string{
+		`CREATE SCHEMA IF NOT EXISTS app_data`,
+		`CREATE TABLE IF NOT EXISTS app_data.alert_rules (
+			id VARCHAR PRIMARY KEY DEFAULT uuid()::VARCHAR,
+			user_id VARCHAR NOT NULL,
+			sport VARCHAR NOT NULL,
+			player VARCHAR NOT NULL,
+			market VARCHAR NOT NULL,
+			sport_book VARCHAR NOT NULL,
+			threshold DOUBLE NOT NULL,
+			threshold_unit VARCHAR NOT NULL,
+			direction VARCHAR NOT NULL,
+			webhook_url VARCHAR NOT NULL,
+			secret VARCHAR NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+		)`,
+		`CREATE TABLE IF NOT EXISTS app_data.alert_deliveries (
+			id VARCHAR PRIMARY KEY DEFAULT uuid()::VARCHAR,
+			rule_id VARCHAR NOT NULL,
+			user_id VARCHAR NOT NULL,
+			change_id BIGINT NOT NULL,
+			webhook_url VARCHAR NOT NULL,
+			attempts INTEGER NOT NULL,
+			status_code INTEGER NOT NULL,
+			success BOOLEAN NOT NULL,
+			error VARCHAR NOT NULL,
+			payload VARCHAR NOT NULL,
+			delivered_at TIMESTAMP NOT NULL
+		)`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to create alert tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateAlertRule stores a rule and returns it with its generated ID and creation time
+func CreateAlertRule(db *sql.DB, rule models.AlertRule) (models.AlertRule, error) {
+	query := `
+		INSERT INTO app_data.alert_rules
+			(user_id, sport, player, market, sport_book, threshold, threshold_unit, direction, webhook_url, secret)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+	err := db.QueryRow(query,
+		rule.UserID, rule.Sport, rule.Player, rule.Market, rule.Sportbook,
+		rule.Threshold, rule.ThresholdUnit, rule.Direction, rule.WebhookURL, rule.Secret,
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		return models.AlertRule{}, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	return rule, nil
+}
+
+const alertRuleColumns = `id, user_id, sport, player, market, sport_book, threshold, threshold_unit, direction, webhook_url, secret, created_at`
+
+// GetAlertRules retrieves a user's alert rules, oldest first. An empty userID retrieves every
+// user's rules.
+func GetAlertRules(db *sql.DB, userID string) ([]models.AlertRule, error) {
+	query := `
+		SELECT ` + alertRuleColumns + `
+		FROM app_data.alert_rules
+		WHERE (? = '' OR user_id = ?)
+		ORDER BY created_at, id
+	`
+	rows, err := db.Query(query, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.AlertRule{}
+	for rows.Next() {
+		var rule models.AlertRule
+		err := rows.Scan(&rule.ID, &rule.UserID, &rule.Sport, &rule.Player, &rule.Market, &rule.Sportbook,
+			&rule.Threshold, &rule.ThresholdUnit, &rule.Direction, &rule.WebhookURL, &rule.Secret, &rule.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert rule row: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over alert rule rows: %w", err)
+	}
+	return rules, nil
+}
+
+// DeleteAlertRule removes one of a user's rules, returning sql.ErrNoRows if it doesn't exist
+func DeleteAlertRule(db *sql.DB, userID, ruleID string) error {
+	result, err := db.Exec(`DELETE FROM app_data.alert_rules WHERE user_id = ? AND id = ?`, userID, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// LogAlertDelivery records the outcome of a webhook delivery
+func LogAlertDelivery(db *sql.DB, delivery models.AlertDelivery) error {
+	query := `
+		INSERT INTO app_data.alert_deliveries
+			(rule_id, user_id, change_id, webhook_url, attempts, status_code, success, error, payload, delivered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := db.Exec(query,
+		delivery.RuleID, delivery.UserID, delivery.ChangeID, delivery.WebhookURL, delivery.Attempts,
+		delivery.StatusCode, delivery.Success, delivery.Error, delivery.Payload, delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to log alert delivery: %w", err)
+	}
+	return nil
+}
+
+// GetAlertDeliveries retrieves a user's most recent webhook deliveries, newest first. An empty
+// ruleID covers all of the user's rules.
+func GetAlertDeliveries(db *sql.DB, userID, ruleID string, limit int) ([]models.AlertDelivery, error) {
+	query := `
+		SELECT id, rule_id, user_id, change_id, webhook_url, attempts, status_code, success, error, payload, delivered_at
+		FROM app_data.alert_deliveries
+		WHERE user_id = ?
+			AND (? = '' OR rule_id = ?)
+		ORDER BY delivered_at DESC
+		LIMIT ?
+	`
+	rows, err := db.Query(query, userID, ruleID, ruleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.AlertDelivery{}
+	for rows.Next() {
+		var d models.AlertDelivery
+		err := rows.Scan(&d.ID, &d.RuleID, &d.UserID, &d.ChangeID, &d.WebhookURL, &d.Attempts,
+			&d.StatusCode, &d.Success, &d.Error, &d.Payload, &d.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert delivery row: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over alert delivery rows: %w", err)
+	}
+	return deliveries, nil
+}
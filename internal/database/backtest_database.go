@@ -0,0 +1,128 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"sports_api/internal/models"
+)
+
+// GetNBAMarketGameStats retrieves each player's value for a prop market's stat in every game
+// played between two dates (YYYY-MM-DD, inclusive), ordered by player and date, with the game's
+// tip-off from the scoreboard. An empty playerID covers every player.
+func GetNBAMarketGameStats(db *sql.DB, market, playerID, from, to string) ([]models.NBAPlayerGameStat, error) {
+	stat, ok := nbaBetStats[market]
+	if !ok {
+		return nil, fmt.Errorf("unknown nba market: %s", market)
+	}
+
+	query := `
+		WITH games AS (
+			SELECT
+				player_id::VARCHAR AS player_id,
+				GAME_ID::VARCHAR AS game_id,
+				CAST(game_date AS DATE) AS game_date,
+				COALESCE(OPPONENT::VARCHAR, '') AS opponent,
+				COALESCE((` + stat.expr + `)::DOUBLE, 0) AS value,
+				COALESCE(minutes_per_game::DOUBLE, 0) AS minutes
+			FROM ` + stat.table + `
+			WHERE CAST(game_date AS DATE) BETWEEN CAST(? AS DATE) AND CAST(? AS DATE)
+				AND COALESCE(minutes_per_game, 0) > 0
+				AND (? = '' OR player_id::VARCHAR = ?)
+			QUALIFY ROW_NUMBER() OVER (PARTITION BY player_id, GAME_ID) = 1
+		),
+		tips AS (
+			SELECT game_id::VARCHAR AS game_id, MIN(CAST(game_time_utc AS TIMESTAMP)) AS tip
+			FROM nba_data.scoreboard
+			GROUP BY game_id
+		)
+		SELECT g.player_id, g.game_id, g.game_date::VARCHAR, g.opponent, g.value, g.minutes, t.tip
+		FROM games g
+		LEFT JOIN tips t ON t.game_id = g.game_id
+		ORDER BY g.player_id, g.game_date, g.game_id
+	`
+	rows, err := db.Query(query, from, to, playerID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market game stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.NBAPlayerGameStat
+	for rows.Next() {
+		var s models.NBAPlayerGameStat
+		var tip sql.NullTime
+		if err := rows.Scan(&s.PlayerID, &s.GameID, &s.GameDate, &s.Opponent, &s.Value, &s.Minutes, &tip); err != nil {
+			return nil, fmt.Errorf("failed to scan market game stat row: %w", err)
+		}
+		s.StartTime = tip.Time
+		stats = append(stats, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over market game stat rows: %w", err)
+	}
+	return stats, nil
+}
+
+// GetNBAHistoricalPropLines retrieves each player's prop line for a market as it stood just before
+// each scoreboard tip-off on dates between from and to: the last snapshot taken in the 12 hours
+// before the tip, which leaves out in-game lines and the previous night's games. The odds don't
+// say which game a line is for, so there's a row per player for every distinct tip time on a
+// date, and callers keep the one matching the player's own game. Lines come from the given book
+// or, when book is empty, from FanDuel, then DraftKings, then BetMGM.
+func GetNBAHistoricalPropLines(db *sql.DB, market, book, from, to string) ([]models.PropLine, error) {
+	query := `
+		WITH tips AS (
+			SELECT DISTINCT
+				CAST(game_date AS DATE) AS game_date,
+				CAST(game_time_utc AS TIMESTAMP) AS tip
+			FROM nba_data.scoreboard
+			WHERE CAST(game_date AS DATE) BETWEEN CAST(? AS DATE) AND CAST(? AS DATE)
+				AND game_time_utc IS NOT NULL
+		),
+		snapshots AS (
+			SELECT player, sport_book, line, over_odds, under_odds, "timestamp"
+			FROM nba_data.nba_prop_odds
+			WHERE market = ?
+				AND (sport_book = ? OR (? = '' AND sport_book IN ('FanDuel', 'DraftKings', 'BetMGM')))
+				AND "timestamp" >= CAST(? AS DATE) - INTERVAL 1 DAY
+				AND "timestamp" < CAST(? AS DATE) + INTERVAL 2 DAY
+		)
+		SELECT
+			s.player,
+			t.game_date::VARCHAR,
+			t.tip,
+			s.sport_book,
+			s.line::DOUBLE,
+			s.over_odds,
+			s.under_odds
+		FROM tips t
+		JOIN snapshots s
+			ON s."timestamp" < t.tip
+			AND s."timestamp" >= t.tip - INTERVAL 12 HOUR
+		QUALIFY ROW_NUMBER() OVER (
+			PARTITION BY s.player, t.tip
+			ORDER BY
+				CASE s.sport_book WHEN 'FanDuel' THEN 1 WHEN 'DraftKings' THEN 2 ELSE 3 END,
+				s."timestamp" DESC
+		) = 1
+		ORDER BY t.tip, s.player
+	`
+	rows, err := db.Query(query, from, to, market, book, book, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query historical prop lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []models.PropLine
+	for rows.Next() {
+		var l models.PropLine
+		if err := rows.Scan(&l.Player, &l.Date, &l.StartTime, &l.Sportbook, &l.Line, &l.Over, &l.Under); err != nil {
+			return nil, fmt.Errorf("failed to scan historical prop line row: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over historical prop line rows: %w", err)
+	}
+	return lines, nil
+}
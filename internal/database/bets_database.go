@@ -0,0 +1,282 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"sports_api/internal/models"
+)
+
+// betStat is the gamelog table and stat expression a prop market settles on
+type betStat struct {
+	table string
+	expr  string
+}
+
+var (
+	nbaBetStats = map[string]betStat{
+		"player_points":                  {"nba_data.player_boxscores", "points"},
+		"player_rebounds":                {"nba_data.player_boxscores", "reboundsTotal"},
+		"player_assists":                 {"nba_data.player_boxscores", "assists"},
+		"player_threes":                  {"nba_data.player_boxscores", "threePointersMade"},
+		"player_points_rebounds_assists": {"nba_data.player_boxscores", "points + reboundsTotal + assists"},
+		"player_points_rebounds":         {"nba_data.player_boxscores", "points + reboundsTotal"},
+		"player_points_assists":          {"nba_data.player_boxscores", "points + assists"},
+		"player_rebounds_assists":        {"nba_data.player_boxscores", "reboundsTotal + assists"},
+	}
+	nflBetStats = map[string]betStat{
+		"player_pass_yds":           {"nfl_data.nfl_qb_gamelog", "passingYards"},
+		"player_pass_tds":           {"nfl_data.nfl_qb_gamelog", "passingTouchdowns"},
+		"player_pass_completions":   {"nfl_data.nfl_qb_gamelog", "passingCompletions"},
+		"player_pass_attempts":      {"nfl_data.nfl_qb_gamelog", "passingAttempts"},
+		"player_pass_interceptions": {"nfl_data.nfl_qb_gamelog", "interceptions"},
+		"player_rush_yds":           {"nfl_data.nfl_player_gamelog", "rushingYards"},
+		"player_rush_attempts":      {"nfl_data.nfl_player_gamelog", "rushingAttempts"},
+		"player_receptions":         {"nfl_data.nfl_player_gamelog", "receptions"},
+		"player_reception_yds":      {"nfl_data.nfl_player_gamelog", "receivingYards"},
+		"player_rush_reception_yds": {"nfl_data.nfl_player_gamelog", "rushingYards + receivingYards"},
+	}
+	betStats = map[string]map[string]betStat{"nba": nbaBetStats, "nfl": nflBetStats}
+)
+
+// BetMarkets lists the prop markets bets can be graded on for a sport
+func BetMarkets(sport string) []string {
+	markets := []string{}
+	for market := range betStats[sport] {
+		markets = append(markets, market)
+	}
+	sort.Strings(markets)
+	return markets
+}
+
+// EnsureBetTables creates the schema and table holding users' bets
+func EnsureBetTables(db *sql.DB) error {
+	statements := []string{
+		`CREATE SCHEMA IF NOT EXISTS app_data`,
+		`CREATE TABLE IF NOT EXISTS app_data.bets (
+			id VARCHAR PRIMARY KEY DEFAULT uuid()::VARCHAR,
+			user_id VARCHAR NOT NULL,
+			sport VARCHAR NOT NULL,
+			player VARCHAR NOT NULL,
+			market VARCHAR NOT NULL,
+			side VARCHAR NOT NULL,
+			line DOUBLE NOT NULL,
+			price INTEGER NOT NULL,
+			sport_book VARCHAR NOT NULL,
+			stake DOUBLE NOT NULL,
+			placed_at TIMESTAMP NOT NULL,
+			status VARCHAR NOT NULL DEFAULT 'pending',
+			game_date VARCHAR NOT NULL DEFAULT '',
+			game_id VARCHAR NOT NULL DEFAULT '',
+			actual DOUBLE,
+			profit DOUBLE NOT NULL DEFAULT 0,
+			closing_line DOUBLE,
+			closing_price INTEGER,
+			graded_at TIMESTAMP
+		)`,
+		`ALTER TABLE app_data.bets ADD COLUMN IF NOT EXISTS game_date VARCHAR DEFAULT ''`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to create bet tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateBet stores a pending bet and returns it with its generated ID
+func CreateBet(db *sql.DB, bet models.Bet) (models.Bet, error) {
+	query := `
+		INSERT INTO app_data.bets
+			(user_id, sport, player, market, side, line, price, sport_book, stake, placed_at, game_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, status
+	`
+	err := db.QueryRow(query,
+		bet.UserID, bet.Sport, bet.Player, bet.Market, bet.Side,
+		bet.Line, bet.Price, bet.Sportbook, bet.Stake, bet.PlacedAt, bet.GameDate,
+	).Scan(&bet.ID, &bet.Status)
+	if err != nil {
+		return models.Bet{}, fmt.Errorf("failed to create bet: %w", err)
+	}
+	return bet, nil
+}
+
+// GetBets retrieves a user's bets, most recently placed first
+func GetBets(db *sql.DB, userID string) ([]models.Bet, error) {
+	query := `
+		SELECT ` + betColumns + `
+		FROM app_data.bets
+		WHERE user_id = ?
+		ORDER BY placed_at DESC, id
+	`
+	return queryBets(db, query, userID)
+}
+
+// GetPendingBets retrieves every user's ungraded bets, oldest first
+func GetPendingBets(db *sql.DB) ([]models.Bet, error) {
+	query := `
+		SELECT ` + betColumns + `
+		FROM app_data.bets
+		WHERE status = 'pending'
+		ORDER BY placed_at, id
+	`
+	return queryBets(db, query)
+}
+
+const betColumns = `id, user_id, sport, player, market, side, line, price, sport_book, stake, placed_at,
+			COALESCE(game_date, ''), status, game_id, actual, profit, closing_line, closing_price, graded_at`
+
+// queryBets runs a query selecting betColumns and scans the bets
+func queryBets(db *sql.DB, query string, args ...any) ([]models.Bet, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bets: %w", err)
+	}
+	defer rows.Close()
+
+	bets := []models.Bet{}
+	for rows.Next() {
+		var bet models.Bet
+		var actual, closingLine sql.NullFloat64
+		var closingPrice sql.NullInt64
+		var gradedAt sql.NullTime
+		err := rows.Scan(&bet.ID, &bet.UserID, &bet.Sport, &bet.Player, &bet.Market, &bet.Side,
+			&bet.Line, &bet.Price, &bet.Sportbook, &bet.Stake, &bet.PlacedAt,
+			&bet.GameDate, &bet.Status, &bet.GameID, &actual, &bet.Profit, &closingLine, &closingPrice, &gradedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bet row: %w", err)
+		}
+		if actual.Valid {
+			bet.Actual = &actual.Float64
+		}
+		if closingLine.Valid {
+			bet.ClosingLine = &closingLine.Float64
+		}
+		if closingPrice.Valid {
+			price := int(closingPrice.Int64)
+			bet.ClosingPrice = &price
+		}
+		if gradedAt.Valid {
+			bet.GradedAt = &gradedAt.Time
+		}
+		bets = append(bets, bet)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over bet rows: %w", err)
+	}
+	return bets, nil
+}
+
+// UpdateBetGrade stores a graded bet's result, profit and closing snapshot
+func UpdateBetGrade(db *sql.DB, bet models.Bet) error {
+	query := `
+		UPDATE app_data.bets
+		SET status = ?, game_id = ?, actual = ?, profit = ?, closing_line = ?, closing_price = ?, graded_at = ?
+		WHERE id = ?
+	`
+	_, err := db.Exec(query, bet.Status, bet.GameID, bet.Actual, bet.Profit, bet.ClosingLine, bet.ClosingPrice, bet.GradedAt, bet.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update bet grade: %w", err)
+	}
+	return nil
+}
+
+// GetBetOutcome finds the stat a bet settles on in the player's game on the bet's game date
+// (YYYY-MM-DD), with the game's start time: the scoreboard tip-off for NBA games and the
+// event kickoff for NFL games. Start is zero when the start isn't known. NBA players are matched
+// by player ID, NFL players by gamelog name. It returns false until that game reaches the gamelogs.
+func GetBetOutcome(db *sql.DB, sport, market, player, gameDate string) (models.BetOutcome, bool, error) {
+	stat, ok := betStats[sport][market]
+	if !ok {
+		return models.BetOutcome{}, false, fmt.Errorf("unknown %s bet market: %s", sport, market)
+	}
+
+	var query string
+	if sport == "nba" {
+		query = `
+			SELECT
+				gl.GAME_ID::VARCHAR,
+				(` + stat.expr + `)::DOUBLE,
+				(SELECT MIN(CAST(sb.game_time_utc AS TIMESTAMP)) FROM nba_data.scoreboard sb WHERE sb.game_id::VARCHAR = gl.GAME_ID::VARCHAR)
+			FROM ` + stat.table + ` gl
+			WHERE gl.player_id::VARCHAR = ?
+				AND CAST(gl.game_date AS DATE) = CAST(? AS DATE)
+			LIMIT 1
+		`
+	} else {
+		query = `
+			SELECT
+				gl.game_id::VARCHAR,
+				(` + stat.expr + `)::DOUBLE,
+				TRY_CAST(ev.event_date AS TIMESTAMP)
+			FROM ` + stat.table + ` gl
+			LEFT JOIN nfl_data.nfl_game_events_db ev ON ev.event_id = gl.game_id
+			WHERE gl.player_name = ?
+				AND CAST(gl.game_date AS DATE) = CAST(? AS DATE)
+			LIMIT 1
+		`
+	}
+
+	var outcome models.BetOutcome
+	var start sql.NullTime
+	err := db.QueryRow(query, player, gameDate).Scan(&outcome.GameID, &outcome.Value, &start)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.BetOutcome{}, false, nil
+	}
+	if err != nil {
+		return models.BetOutcome{}, false, fmt.Errorf("failed to query bet outcome: %w", err)
+	}
+	outcome.Start = start.Time
+	return outcome, true, nil
+}
+
+// BetGamelogsPast reports whether a market's gamelogs hold games after a date (YYYY-MM-DD),
+// meaning that date's games have all been loaded
+func BetGamelogsPast(db *sql.DB, sport, market, gameDate string) (bool, error) {
+	stat, ok := betStats[sport][market]
+	if !ok {
+		return false, fmt.Errorf("unknown %s bet market: %s", sport, market)
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM ` + stat.table + ` WHERE CAST(game_date AS DATE) > CAST(? AS DATE))`
+	var past bool
+	if err := db.QueryRow(query, gameDate).Scan(&past); err != nil {
+		return false, fmt.Errorf("failed to query gamelog dates: %w", err)
+	}
+	return past, nil
+}
+
+// GetClosingOdds retrieves the last snapshot of a player's market at a sportsbook taken between a
+// bet being placed and the game starting, leaving out in-game snapshots. Player names match ignoring case and accents.
+func GetClosingOdds(db *sql.DB, sport, player, market, book string, placedAt, start time.Time) (models.Odds, bool, error) {
+	table, ok := propOddsTables[sport]
+	if !ok {
+		return models.Odds{}, false, fmt.Errorf("unknown odds sport: %s", sport)
+	}
+
+	query := `
+		SELECT player, sport_book, market, line, over_odds, under_odds
+		FROM ` + table + `
+		WHERE lower(strip_accents(player)) = lower(strip_accents(?))
+			AND market = ?
+			AND sport_book = ?
+			AND "timestamp" >= ?
+			AND "timestamp" < ?
+		ORDER BY "timestamp" DESC
+		LIMIT 1
+	`
+	var odd models.Odds
+	err := db.QueryRow(query, player, market, book, placedAt, start).
+		Scan(&odd.Name, &odd.Sportbook, &odd.Market, &odd.Line, &odd.Over, &odd.Under)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Odds{}, false, nil
+	}
+	if err != nil {
+		return models.Odds{}, false, fmt.Errorf("failed to query closing odds: %w", err)
+	}
+	return odd, true, nil
+}
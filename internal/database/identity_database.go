@@ -0,0 +1,156 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sports_api/internal/identity"
+)
+
+// NBA player name sources checked by the unmatched names report, keyed by source name
+var nbaPlayerNameSources = map[string]string{
+	"injuries": `SELECT DISTINCT "Player Name" FROM nba_data.nba_injuries_status WHERE "Player Name" IS NOT NULL`,
+	"odds":     `SELECT DISTINCT player FROM nba_data.nba_prop_odds WHERE player IS NOT NULL`,
+}
+
+// GetNBARosterNames retrieves every rostered NBA player as player ID to display name
+func GetNBARosterNames(db *sql.DB) (map[string]string, error) {
+	query := `SELECT DISTINCT PLAYER_ID::VARCHAR, PLAYER FROM nba_data.team_roster WHERE PLAYER IS NOT NULL`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NBA roster names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan NBA roster name row: %w", err)
+		}
+		names[id] = name
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over NBA roster name rows: %w", err)
+	}
+
+	return names, nil
+}
+
+// EnsureIdentityTables creates the app-owned schema and the NBA player alias table
+func EnsureIdentityTables(db *sql.DB) error {
+	statements := []string{
+		`CREATE SCHEMA IF NOT EXISTS app_data`,
+		`CREATE TABLE IF NOT EXISTS app_data.nba_player_aliases (
+			source VARCHAR NOT NULL,
+			alias VARCHAR NOT NULL,
+			player_id VARCHAR NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+		)`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to create identity tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetNBAPlayerAliases retrieves the alias table as alias to player ID. Aliases cover names the
+// identity normalization rules can't resolve on their own. A database without the alias table
+// has no aliases.
+func GetNBAPlayerAliases(db *sql.DB) (map[string]string, error) {
+	var tables int
+	err := db.QueryRow(`
+		SELECT COUNT(*)
+		FROM information_schema.tables
+		WHERE table_schema = 'app_data' AND table_name = 'nba_player_aliases'
+	`).Scan(&tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up NBA player alias table: %w", err)
+	}
+	if tables == 0 {
+		return map[string]string{}, nil
+	}
+
+	query := `SELECT alias, player_id FROM app_data.nba_player_aliases`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NBA player aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := make(map[string]string)
+	for rows.Next() {
+		var alias, id string
+		if err := rows.Scan(&alias, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan NBA player alias row: %w", err)
+		}
+		aliases[alias] = id
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over NBA player alias rows: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// AddNBAPlayerAlias records that a source refers to a player by alias
+func AddNBAPlayerAlias(db *sql.DB, source, alias, playerID string) error {
+	query := `INSERT INTO app_data.nba_player_aliases (source, alias, player_id) VALUES (?, ?, ?)`
+
+	if _, err := db.Exec(query, source, alias, playerID); err != nil {
+		return fmt.Errorf("failed to add NBA player alias: %w", err)
+	}
+	return nil
+}
+
+// NewNBAPlayerResolver builds an identity resolver from the roster and alias table
+func NewNBAPlayerResolver(db *sql.DB) (*identity.Resolver, error) {
+	roster, err := GetNBARosterNames(db)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := GetNBAPlayerAliases(db)
+	if err != nil {
+		return nil, err
+	}
+	return identity.NewResolver(roster, aliases), nil
+}
+
+// GetNBASourcePlayerNames retrieves the distinct player names used by a source ("injuries" or "odds")
+func GetNBASourcePlayerNames(db *sql.DB, source string) ([]string, error) {
+	query, ok := nbaPlayerNameSources[source]
+	if !ok {
+		return nil, fmt.Errorf("unknown player name source: %s", source)
+	}
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s player names: %w", source, err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan %s player name row: %w", source, err)
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over %s player name rows: %w", source, err)
+	}
+
+	return names, nil
+}
+
+// NBAPlayerNameSources lists the sources accepted by GetNBASourcePlayerNames
+func NBAPlayerNameSources() []string {
+	return []string{"injuries", "odds"}
+}
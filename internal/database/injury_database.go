@@ -0,0 +1,138 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sports_api/internal/models"
+)
+
+// GetNBALatestInjuries retrieves each team's most recent injury report
+func GetNBALatestInjuries(db *sql.DB) ([]models.NBAInjuryStatus, error) {
+	query := `
+		SELECT 
+			"Player Name",
+			Team,
+			COALESCE("Current Status", ''),
+			ingested_date::VARCHAR
+		FROM nba_data.nba_injuries_status t1
+		WHERE ingested_date = (
+			SELECT MAX(ingested_date)
+			FROM nba_data.nba_injuries_status t2
+			WHERE t2.Team = t1.Team
+		)
+		ORDER BY Team, "Player Name"
+	`
+	return queryNBAInjuries(db, query)
+}
+
+// GetNBAInjuryHistory retrieves every injury report row, oldest ingestion first
+func GetNBAInjuryHistory(db *sql.DB) ([]models.NBAInjuryStatus, error) {
+	query := `
+		SELECT 
+			"Player Name",
+			Team,
+			COALESCE("Current Status", ''),
+			ingested_date::VARCHAR
+		FROM nba_data.nba_injuries_status
+		ORDER BY Team, "Player Name", ingested_date
+	`
+	return queryNBAInjuries(db, query)
+}
+
+func queryNBAInjuries(db *sql.DB, query string) ([]models.NBAInjuryStatus, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NBA injuries: %w", err)
+	}
+	defer rows.Close()
+
+	var injuries []models.NBAInjuryStatus
+	for rows.Next() {
+		var injury models.NBAInjuryStatus
+		err := rows.Scan(&injury.PlayerName, &injury.Team, &injury.Status, &injury.IngestedDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan NBA injury row: %w", err)
+		}
+		injuries = append(injuries, injury)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over NBA injury rows: %w", err)
+	}
+
+	return injuries, nil
+}
+
+// GetNBAInjuryChanges compares each team's latest injury report with the one before it and
+// returns the players whose status changed, including players added to or dropped from the report
+func GetNBAInjuryChanges(db *sql.DB) ([]models.NBAInjuryChange, error) {
+	query := `
+		WITH ingestions AS (
+			SELECT
+				Team,
+				ingested_date,
+				DENSE_RANK() OVER (PARTITION BY Team ORDER BY ingested_date DESC) AS recency
+			FROM (SELECT DISTINCT Team, ingested_date FROM nba_data.nba_injuries_status)
+		),
+		reports AS (
+			SELECT s."Player Name" AS player_name, s.Team AS team, COALESCE(s."Current Status", '') AS status,
+				s.ingested_date, i.recency
+			FROM nba_data.nba_injuries_status s
+			JOIN ingestions i ON i.Team = s.Team AND i.ingested_date = s.ingested_date
+			WHERE i.recency <= 2
+		),
+		latest AS (SELECT * FROM reports WHERE recency = 1),
+		previous AS (SELECT * FROM reports WHERE recency = 2),
+		report_dates AS (
+			SELECT
+				Team,
+				MAX(CASE WHEN recency = 1 THEN ingested_date END)::VARCHAR AS latest_date,
+				COALESCE(MAX(CASE WHEN recency = 2 THEN ingested_date END)::VARCHAR, '') AS previous_date
+			FROM ingestions
+			GROUP BY Team
+		)
+		SELECT
+			COALESCE(l.player_name, p.player_name) AS player_name,
+			COALESCE(l.team, p.team) AS team,
+			COALESCE(p.status, '') AS previous_status,
+			COALESCE(l.status, '') AS status,
+			d.previous_date,
+			d.latest_date
+		FROM latest l
+		FULL OUTER JOIN previous p ON l.team = p.team AND l.player_name = p.player_name
+		JOIN report_dates d ON d.Team = COALESCE(l.team, p.team)
+		WHERE COALESCE(l.status, '') <> COALESCE(p.status, '')
+			-- a team with a single report has nothing to compare against
+			AND d.previous_date <> ''
+		ORDER BY d.latest_date DESC, team, player_name
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NBA injury changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []models.NBAInjuryChange
+	for rows.Next() {
+		var change models.NBAInjuryChange
+		err := rows.Scan(
+			&change.PlayerName,
+			&change.Team,
+			&change.PreviousStatus,
+			&change.Status,
+			&change.PreviousIngestedDate,
+			&change.IngestedDate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan NBA injury change row: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over NBA injury change rows: %w", err)
+	}
+
+	return changes, nil
+}
@@ -3,26 +3,56 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"sports_api/internal/identity"
 	"sports_api/internal/models"
 )
 
 // NBA Database operations
 
-func GetScoreboard(db *sql.DB) ([]models.Game, error) {
-	query := `SELECT game_id, home_team_city, home_team_name, away_team_city, away_team_name FROM nba_data.scoreboard`
-	rows, err := db.Query(query)
+// GetScoreboard retrieves the games on a date (YYYY-MM-DD, Eastern) from nba_data.scoreboard with
+// their status, period, clock, scores and UTC start time. An empty date uses the latest date on
+// the scoreboard.
+func GetScoreboard(db *sql.DB, gameDate string) ([]models.Game, error) {
+	query := `
+		SELECT
+			game_id,
+			home_team_city,
+			home_team_name,
+			away_team_city,
+			away_team_name,
+			CAST(game_date AS DATE)::VARCHAR,
+			COALESCE(game_status, 0)::INTEGER,
+			COALESCE(game_status_text, ''),
+			COALESCE(period, 0)::INTEGER,
+			COALESCE(game_clock, ''),
+			CAST(game_time_utc AS TIMESTAMP),
+			COALESCE(home_team_score, 0)::INTEGER,
+			COALESCE(away_team_score, 0)::INTEGER
+		FROM nba_data.scoreboard
+		WHERE CAST(game_date AS DATE) = COALESCE(
+			TRY_CAST(NULLIF(?, '') AS DATE),
+			(SELECT MAX(CAST(game_date AS DATE)) FROM nba_data.scoreboard))
+		ORDER BY game_time_utc, game_id
+	`
+	rows, err := db.Query(query, gameDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query Scoreboard: %w", err)
 	}
 	defer rows.Close()
 
-	var games []models.Game
+	games := []models.Game{}
 	for rows.Next() {
 		var game models.Game
-		err := rows.Scan(&game.GameID, &game.HomeCity, &game.HomeTeam, &game.AwayCity, &game.AwayTeam)
+		var startTime sql.NullTime
+		err := rows.Scan(&game.GameID, &game.HomeCity, &game.HomeTeam, &game.AwayCity, &game.AwayTeam,
+			&game.GameDate, &game.StatusCode, &game.StatusText, &game.Period, &game.GameClock, &startTime,
+			&game.HomeScore, &game.AwayScore)
 		if err != nil {
 			return nil, fmt.Errorf("failed to game: %w", err)
 		}
+		if startTime.Valid {
+			game.StartTime = &startTime.Time
+		}
 		games = append(games, game)
 	}
 	if err = rows.Err(); err != nil {
@@ -31,26 +61,19 @@ func GetScoreboard(db *sql.DB) ([]models.Game, error) {
 	return games, nil
 }
 
-// GetNBAPlayersByTeam retrieves all players for a given NBA team
-func GetNBAPlayersByTeam(db *sql.DB, teamCity string) ([]models.Player, error) {
+// GetNBAPlayersByTeam retrieves all players for the NBA team with the given team ID. Injury statuses are matched
+// through the player identity resolver because the injury report spells names differently
+// from the roster (suffixes, "Last, First", nicknames).
+func GetNBAPlayersByTeam(db *sql.DB, teamID string) ([]models.Player, error) {
 	query := `
-		SELECT PLAYER_ID, PLAYER, "POSITION", tr.TEAM, NUM, COALESCE(nis."Current Status", '') as current_status
+		SELECT PLAYER_ID, PLAYER, "POSITION", tr.TEAM, NUM
 		FROM nba_data.team_roster tr
-		left join(SELECT 
-			"Player Name",
-			"Current Status",
-		FROM nba_data.nba_injuries_status t1
-		WHERE ingested_date = (
-			SELECT MAX(ingested_date)
-			FROM nba_data.nba_injuries_status t2
-			WHERE t2.Team = t1.Team
-		)) nis ON nis."Player Name" = tr.PLAYER 
-		WHERE tr.TEAM = ?
+		WHERE tr.TeamID::VARCHAR = ?
 		AND NUM IS NOT NULL
 		order by PLAYER;
 	`
 
-	rows, err := db.Query(query, teamCity)
+	rows, err := db.Query(query, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query NBA players: %w", err)
 	}
@@ -59,7 +82,7 @@ func GetNBAPlayersByTeam(db *sql.DB, teamCity string) ([]models.Player, error) {
 	var players []models.Player
 	for rows.Next() {
 		var player models.Player
-		err := rows.Scan(&player.PlayerID, &player.PlayerName, &player.Position, &player.TeamID, &player.Number, &player.Status)
+		err := rows.Scan(&player.PlayerID, &player.PlayerName, &player.Position, &player.TeamID, &player.Number)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan NBA player row: %w", err)
 		}
@@ -72,9 +95,39 @@ func GetNBAPlayersByTeam(db *sql.DB, teamCity string) ([]models.Player, error) {
 		return nil, fmt.Errorf("error iterating over NBA player rows: %w", err)
 	}
 
+	statuses, err := getNBACurrentInjuryStatuses(db)
+	if err != nil {
+		return nil, err
+	}
+	for i := range players {
+		players[i].Status = statuses[players[i].PlayerID]
+	}
+
 	return players, nil
 }
 
+// getNBACurrentInjuryStatuses retrieves each team's latest injury report as player ID to status
+func getNBACurrentInjuryStatuses(db *sql.DB) (map[string]string, error) {
+	resolver, err := NewNBAPlayerResolver(db)
+	if err != nil {
+		return nil, err
+	}
+
+	injuries, err := GetNBALatestInjuries(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]string)
+	for _, injury := range injuries {
+		if id, ok := resolver.Resolve(injury.PlayerName); ok {
+			statuses[id] = injury.Status
+		}
+	}
+
+	return statuses, nil
+}
+
 // GetNBATeams retrieves all NBA teams
 func GetNBATeams(db *sql.DB) ([]models.Team, error) {
 	query := `
@@ -105,8 +158,8 @@ func GetNBATeams(db *sql.DB) ([]models.Team, error) {
 	return teams, nil
 }
 
-// GetPlayerLastXGames retrieves a player's last X games
-func GetPlayerLastXGames(db *sql.DB, playerName string, lastXGames int) (map[string]models.NBAGameStats, error) {
+// GetPlayerLastXGames retrieves a player's last X games by player ID, limited to a season when seasonID is non-empty
+func GetPlayerLastXGames(db *sql.DB, playerID string, seasonID string, lastXGames int) (map[string]models.NBAGameStats, error) {
 	query := `
 		SELECT 
 			game_date, 
@@ -117,15 +170,15 @@ func GetPlayerLastXGames(db *sql.DB, playerName string, lastXGames int) (map[str
 			minutes_per_game
 		FROM 
 			nba_data.player_boxscores bx
-			JOIN nba_data.team_roster tr ON bx.player_id = tr.player_id
 		WHERE 
-			tr.PLAYER = ?
+			bx.player_id::VARCHAR = ?
+			AND (? = '' OR ` + nbaSeasonSQL("bx.game_date") + ` = ?)
 		ORDER BY 
 			GAME_ID DESC
 		LIMIT ?
 	`
 
-	rows, err := db.Query(query, playerName, lastXGames)
+	rows, err := db.Query(query, playerID, seasonID, seasonID, lastXGames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query player game logs: %w", err)
 	}
@@ -149,17 +202,18 @@ func GetPlayerLastXGames(db *sql.DB, playerName string, lastXGames int) (map[str
 	return gameLogs, nil
 }
 
-// GetTeamLastXGames retrieves a team's last X games
-func GetTeamLastXGames(db *sql.DB, teamCity string, lastXGames int) (map[string]models.TeamGameLog, error) {
+// GetTeamLastXGames retrieves a team's last X games by team ID, limited to a season when seasonID is non-empty
+func GetTeamLastXGames(db *sql.DB, teamID string, seasonID string, lastXGames int) (map[string]models.TeamGameLog, error) {
 	query := `
 		SELECT GAME_DATE, PTS 
 		FROM nba_data.team_boxscores 
-		WHERE TEAM_CITY = ? 
+		WHERE TEAM_ID::VARCHAR = ? 
+			AND (? = '' OR ` + nbaSeasonSQL("GAME_DATE") + ` = ?)
 		ORDER BY GAME_ID DESC 
 		LIMIT ?
 	`
 
-	rows, err := db.Query(query, teamCity, lastXGames)
+	rows, err := db.Query(query, teamID, seasonID, seasonID, lastXGames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query team game logs: %w", err)
 	}
@@ -182,8 +236,8 @@ func GetTeamLastXGames(db *sql.DB, teamCity string, lastXGames int) (map[string]
 	return gameLogs, nil
 }
 
-// GetTeamDefenseStats retrieves team defensive statistics
-func GetTeamDefenseStats(db *sql.DB, teamName string) (*models.NBATeamDefenseStats, error) {
+// GetTeamDefenseStats retrieves a team's defensive statistics for a season by team ID
+func GetTeamDefenseStats(db *sql.DB, teamID string, seasonID string) (*models.NBATeamDefenseStats, error) {
 	query := `
 		SELECT 
 			opp.OPP_FGA_RANK, 
@@ -214,20 +268,22 @@ func GetTeamDefenseStats(db *sql.DB, teamName string) (*models.NBATeamDefenseSta
 			ff.OPP_FTA_RATE,
 			ff.OPP_OREB_PCT_RANK, 
 			ff.OPP_OREB_PCT, 
-			opp.TEAM_NAME
+			opp.TEAM_NAME,
+			opp.SEASON
 		FROM 
 			nba_data.teams_opponent_stats opp
-			JOIN nba_data.teams_defense_stats def ON opp.TEAM_ID = def.TEAM_ID
-			JOIN nba_data.teams_advanced_stats adv ON opp.TEAM_ID = adv.TEAM_ID
-			JOIN nba_data.teams_four_factors_stats ff ON opp.TEAM_ID = ff.TEAM_ID
+			JOIN nba_data.teams_defense_stats def ON opp.TEAM_ID = def.TEAM_ID AND opp.SEASON = def.SEASON
+			JOIN nba_data.teams_advanced_stats adv ON opp.TEAM_ID = adv.TEAM_ID AND opp.SEASON = adv.SEASON
+			JOIN nba_data.teams_four_factors_stats ff ON opp.TEAM_ID = ff.TEAM_ID AND opp.SEASON = ff.SEASON
 		WHERE 
-			opp.TEAM_NAME = ?
+			opp.TEAM_ID::VARCHAR = ?
+			AND opp.SEASON = ?
 		LIMIT 1
 	`
 
 	var stats models.NBATeamDefenseStats
 
-	err := db.QueryRow(query, teamName).Scan(
+	err := db.QueryRow(query, teamID, seasonID).Scan(
 		&stats.OppFgaRank, &stats.OppFga, &stats.OppFgPctRank, &stats.OppFgPct,
 		&stats.OppFtaRank, &stats.OppFta, &stats.OppFtPctRank, &stats.OppFtPct,
 		&stats.OppRebRank, &stats.OppReb, &stats.OppAstRank, &stats.OppAst,
@@ -235,7 +291,7 @@ func GetTeamDefenseStats(db *sql.DB, teamName string) (*models.NBATeamDefenseSta
 		&stats.DefRatingRank, &stats.DefRating, &stats.OppPtsPaintRank, &stats.OppPtsPaint,
 		&stats.PaceRank, &stats.Pace,
 		&stats.OppEfgPctRank, &stats.OppEfgPct, &stats.OppFtaRateRank, &stats.OppFtaRate,
-		&stats.OppOrebPctRank, &stats.OppOrebPct, &stats.TeamName,
+		&stats.OppOrebPctRank, &stats.OppOrebPct, &stats.TeamName, &stats.Season,
 	)
 
 	if err != nil {
@@ -245,7 +301,8 @@ func GetTeamDefenseStats(db *sql.DB, teamName string) (*models.NBATeamDefenseSta
 	return &stats, nil
 }
 
-func GetTeamOffenseStats(db *sql.DB, teamName string) (*models.NBATeamOffenseStats, error) {
+// GetTeamOffenseStats retrieves a team's offensive statistics for a season by team ID
+func GetTeamOffenseStats(db *sql.DB, teamID string, seasonID string) (*models.NBATeamOffenseStats, error) {
 	query := `
 	SELECT 
 			adv.OFF_RATING_RANK, 
@@ -264,22 +321,24 @@ func GetTeamOffenseStats(db *sql.DB, teamName string) (*models.NBATeamOffenseSta
 			ff.TM_TOV_PCT,
 			adv.OREB_PCT_RANK, 
 			adv.OREB_PCT, 
-			adv.TEAM_NAME
+			adv.TEAM_NAME,
+			adv.SEASON
 		FROM 
 			nba_data.teams_advanced_stats adv
-			JOIN nba_data.teams_four_factors_stats ff ON adv.TEAM_ID = ff.TEAM_ID
+			JOIN nba_data.teams_four_factors_stats ff ON adv.TEAM_ID = ff.TEAM_ID AND adv.SEASON = ff.SEASON
 		WHERE 
-			adv.TEAM_NAME = ?
+			adv.TEAM_ID::VARCHAR = ?
+			AND adv.SEASON = ?
 		LIMIT 1
 	`
 
 	var stats models.NBATeamOffenseStats
 
-	err := db.QueryRow(query, teamName).Scan(
+	err := db.QueryRow(query, teamID, seasonID).Scan(
 		&stats.OffRatingRank, &stats.OffRating, &stats.RebPctRank, &stats.RebPct,
 		&stats.AstPctRank, &stats.AstPct, &stats.PaceRank, &stats.Pace,
 		&stats.EfgPctRank, &stats.EfgPct, &stats.FtaRateRank, &stats.FtaRate,
-		&stats.TmTovPctRank, &stats.TmTovPct, &stats.OrebPctRank, &stats.OrebPct, &stats.TeamName,
+		&stats.TmTovPctRank, &stats.TmTovPct, &stats.OrebPctRank, &stats.OrebPct, &stats.TeamName, &stats.Season,
 	)
 
 	if err != nil {
@@ -289,8 +348,9 @@ func GetTeamOffenseStats(db *sql.DB, teamName string) (*models.NBATeamOffenseSta
 	return &stats, nil
 }
 
-// GetPlayerShootingSplits retrieves player shooting splits
-func GetPlayerShootingSplits(db *sql.DB, playerName string) (*models.NBAPlayerShootingSplits, error) {
+// GetPlayerShootingSplits retrieves a player's shooting splits for a season by player ID,
+// labelled with playerName
+func GetPlayerShootingSplits(db *sql.DB, playerID, playerName string, seasonID string) (*models.NBAPlayerShootingSplits, error) {
 	query := `
 		SELECT 
 			FG2A, 
@@ -306,15 +366,16 @@ func GetPlayerShootingSplits(db *sql.DB, playerName string) (*models.NBAPlayerSh
 			FG2A_FREQUENCY, 
 			FG3A_FREQUENCY
 		FROM nba_data.player_shooting_splits ssp
-		JOIN nba_data.team_roster tr on ssp.player_id = tr.player_id
-		WHERE tr.PLAYER = ?
+		WHERE ssp.player_id::VARCHAR = ?
+			AND ssp.SEASON = ?
 		LIMIT 1
 	`
 
 	var splits models.NBAPlayerShootingSplits
 	splits.PlayerName = playerName
+	splits.Season = seasonID
 
-	err := db.QueryRow(query, playerName).Scan(
+	err := db.QueryRow(query, playerID, seasonID).Scan(
 		&splits.Fg2a, &splits.Fg2m, &splits.Fg2Pct, &splits.Fg3a, &splits.Fg3m, &splits.Fg3Pct,
 		&splits.Fga, &splits.Fgm, &splits.FgPct, &splits.EfgPct, &splits.Fg2aFrequency, &splits.Fg3aFrequency,
 	)
@@ -326,8 +387,9 @@ func GetPlayerShootingSplits(db *sql.DB, playerName string) (*models.NBAPlayerSh
 	return &splits, nil
 }
 
-// GetPlayerHeadlineStats retrieves player headline statistics
-func GetPlayerHeadlineStats(db *sql.DB, playerName string) (*models.NBAPlayerHeadlineStats, error) {
+// GetPlayerHeadlineStats retrieves a player's headline statistics for a season by player ID,
+// labelled with playerName
+func GetPlayerHeadlineStats(db *sql.DB, playerID, playerName string, seasonID string) (*models.NBAPlayerHeadlineStats, error) {
 	query := `
 		SELECT 
 			PTS, 
@@ -335,18 +397,17 @@ func GetPlayerHeadlineStats(db *sql.DB, playerName string) (*models.NBAPlayerHea
 			REB
 		FROM 
 			nba_data.player_headline_stats phs
-		JOIN 
-			nba_data.team_roster tr 
-			ON phs.player_id = tr.player_id
 		WHERE 
-			tr.PLAYER = ?
+			phs.player_id::VARCHAR = ?
+			AND phs.SEASON = ?
 		LIMIT 1
 	`
 
 	var stats models.NBAPlayerHeadlineStats
 	stats.PlayerName = playerName
+	stats.Season = seasonID
 
-	err := db.QueryRow(query, playerName).Scan(&stats.Points, &stats.Assists, &stats.Rebounds)
+	err := db.QueryRow(query, playerID, seasonID).Scan(&stats.Points, &stats.Assists, &stats.Rebounds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query player headline stats: %w", err)
 	}
@@ -367,12 +428,33 @@ func GetPlayerIDByName(db *sql.DB, playerName string) (string, error) {
 	return playerID, nil
 }
 
-// GetTeamIDByName retrieves team ID by name
+// GetPlayerNameByID retrieves a player's display name by ID
+func GetPlayerNameByID(db *sql.DB, playerID string) (string, error) {
+	query := `SELECT PLAYER_NAME FROM nba_data.nba_roster_db WHERE PLAYER_ID::VARCHAR = ? LIMIT 1`
+
+	var playerName string
+	err := db.QueryRow(query, playerID).Scan(&playerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get player name: %w", err)
+	}
+
+	return playerName, nil
+}
+
+// GetTeamIDByName retrieves team ID by name, falling back to the team city used by team_roster
 func GetTeamIDByName(db *sql.DB, teamName string) (string, error) {
-	query := `SELECT TEAM_ID FROM nba_data.nba_roster_db WHERE TEAM_NAME = ? LIMIT 1`
+	query := `
+		SELECT team_id FROM (
+			SELECT TEAM_ID::VARCHAR AS team_id, 1 AS priority FROM nba_data.nba_roster_db WHERE TEAM_NAME = ?
+			UNION ALL
+			SELECT TeamID::VARCHAR AS team_id, 2 AS priority FROM nba_data.team_roster WHERE TEAM = ?
+		)
+		ORDER BY priority
+		LIMIT 1
+	`
 
 	var teamID string
-	err := db.QueryRow(query, teamName).Scan(&teamID)
+	err := db.QueryRow(query, teamName, teamName).Scan(&teamID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get team ID: %w", err)
 	}
@@ -380,7 +462,27 @@ func GetTeamIDByName(db *sql.DB, teamName string) (string, error) {
 	return teamID, nil
 }
 
-func GetPlayerShotChartStats(db *sql.DB, playerName string, seasonID string) ([]models.NBAPlayerShotChartStats, error) {
+// GetTeamNamesByID retrieves a team's city (as used by team_roster and box scores) and team name by ID
+func GetTeamNamesByID(db *sql.DB, teamID string) (string, string, error) {
+	query := `
+		SELECT tr.TEAM, COALESCE(r.TEAM_NAME, '')
+		FROM nba_data.team_roster tr
+		LEFT JOIN nba_data.nba_roster_db r ON r.TEAM_ID::VARCHAR = tr.TeamID::VARCHAR
+		WHERE tr.TeamID::VARCHAR = ?
+		LIMIT 1
+	`
+
+	var city, name string
+	err := db.QueryRow(query, teamID).Scan(&city, &name)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get team names: %w", err)
+	}
+
+	return city, name, nil
+}
+
+// GetPlayerShotChartStats retrieves a player's on-court shots for a season by player ID
+func GetPlayerShotChartStats(db *sql.DB, playerID string, seasonID string) ([]models.NBAPlayerShotChartStats, error) {
 	query := `
 		SELECT
 		  psr.GAME_DATE,
@@ -390,14 +492,13 @@ func GetPlayerShotChartStats(db *sql.DB, playerName string, seasonID string) ([]
 		  pb.OPPONENT as opponent
         FROM nba_data.player_shotchart psr
 		JOIN nba_data.player_boxscores pb on psr.player_id = pb.player_id and psr.game_id = pb.GAME_ID
-		JOIN nba_data.team_roster tr on psr.player_id = tr.PLAYER_ID
-        WHERE tr.PLAYER = ?
+        WHERE psr.player_id::VARCHAR = ?
           AND psr.SEASON = ?
           AND psr.LOC_X BETWEEN -250 AND 250
           AND psr.LOC_Y BETWEEN -50 AND 470
 	`
 
-	rows, err := db.Query(query, playerName, seasonID)
+	rows, err := db.Query(query, playerID, seasonID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query player shot chart stats: %w", err)
 	}
@@ -427,7 +528,8 @@ func GetPlayerShotChartStats(db *sql.DB, playerName string, seasonID string) ([]
 	return stats, nil
 }
 
-func GetPlayerAvgShotChartStats(db *sql.DB, playerName string, seasonID string) ([]models.NBAPlayerAvgShotChartStats, error) {
+// GetPlayerAvgShotChartStats retrieves a player's attempts and makes by shot zone for a season by player ID
+func GetPlayerAvgShotChartStats(db *sql.DB, playerID string, seasonID string) ([]models.NBAPlayerAvgShotChartStats, error) {
 	query := `
 		SELECT
           SHOT_ZONE_BASIC,
@@ -437,12 +539,11 @@ func GetPlayerAvgShotChartStats(db *sql.DB, playerName string, seasonID string)
           (made/attempts) *100 ::DOUBLE AS fg_pct
         FROM nba_data.player_shotchart psr
 		JOIN nba_data.player_boxscores pb on psr.player_id = pb.player_id and psr.game_id = pb.GAME_ID
-		JOIN nba_data.team_roster tr on psr.player_id = tr.player_id
-        WHERE tr.PLAYER = ?
+        WHERE psr.player_id::VARCHAR = ?
           AND psr.SEASON = ?
         GROUP BY SHOT_ZONE_BASIC,SHOT_ZONE_AREA
 	`
-	rows, err := db.Query(query, playerName, seasonID)
+	rows, err := db.Query(query, playerID, seasonID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query player avg shot chart stats: %w", err)
 	}
@@ -465,7 +566,9 @@ func GetPlayerAvgShotChartStats(db *sql.DB, playerName string, seasonID string)
 	return stats, nil
 }
 
-func GetOpponentZonesByTeamSeason(db *sql.DB, teamName, season string) ([]models.ZoneValue, error) {
+// GetOpponentZonesByTeamSeason retrieves what a team, by team ID, allows by shot zone in a season
+// with league ranks, from the latest ingest
+func GetOpponentZonesByTeamSeason(db *sql.DB, teamID, season string) ([]models.ZoneValue, error) {
 	// FG_RANK and OUT_OF are now persisted in the table by the Python pipeline.
 	query := `
         SELECT 
@@ -480,11 +583,10 @@ func GetOpponentZonesByTeamSeason(db *sql.DB, teamName, season string) ([]models
 			nba_data.shooting_zones_defense
 		WHERE 
 			SEASON=?
-		QUALIFY TEAM_NAME = ? AND INGESTED_DATE = (SELECT MAX(INGESTED_DATE) FROM nba_data.shooting_zones_defense WHERE SEASON=?)
+		QUALIFY TEAM_ID::VARCHAR = ? AND INGESTED_DATE = (SELECT MAX(INGESTED_DATE) FROM nba_data.shooting_zones_defense WHERE SEASON=?)
     `
 
-	// only two args – season, teamAbbr
-	rows, err := db.Query(query, season, teamName, season)
+	rows, err := db.Query(query, season, teamID, season)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query opponent zones: %w", err)
 	}
@@ -577,3 +679,263 @@ func GetMoneylineOdds(db *sql.DB, team string) ([]models.MoneylineOdds, error) {
 	}
 	return odds, nil
 }
+
+// GetScoreboardMoneylineOdds retrieves, for each game on a scoreboard date (as GetScoreboard reads
+// it), every team's last moneyline price at each tracked sportsbook in the day before tip-off,
+// keyed by game ID. Callers pick out the two sides' prices.
+func GetScoreboardMoneylineOdds(db *sql.DB, gameDate string) (map[string][]models.MoneylineOdds, error) {
+	query := `
+		WITH games AS (
+			SELECT game_id, CAST(game_time_utc AS TIMESTAMP) AS tip
+			FROM nba_data.scoreboard
+			WHERE CAST(game_date AS DATE) = COALESCE(
+				TRY_CAST(NULLIF(?, '') AS DATE),
+				(SELECT MAX(CAST(game_date AS DATE)) FROM nba_data.scoreboard))
+				AND game_time_utc IS NOT NULL
+		)
+		SELECT g.game_id::VARCHAR, o.team, o.sport_book, o.price
+		FROM games g
+		JOIN nba_data.nba_moneyline_odds o
+			ON o."timestamp" < g.tip
+			AND o."timestamp" >= g.tip - INTERVAL 1 DAY
+		WHERE o.sport_book IN ('FanDuel', 'DraftKings', 'BetMGM')
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY g.game_id, o.team, o.sport_book ORDER BY o."timestamp" DESC) = 1
+		ORDER BY g.game_id, o.team, o.sport_book
+	`
+
+	rows, err := db.Query(query, gameDate)
+	if err != nil {
+		return nil, fmt.Errorf("error querying odds: %w", err)
+	}
+	defer rows.Close()
+
+	odds := make(map[string][]models.MoneylineOdds)
+	for rows.Next() {
+		var gameID string
+		var odd models.MoneylineOdds
+		err := rows.Scan(&gameID, &odd.Team, &odd.Sportbook, &odd.Price)
+		if err != nil {
+			return nil, fmt.Errorf("error in odds: %w", err)
+		}
+		odds[gameID] = append(odds[gameID], odd)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over moneyline odds rows: %w", err)
+	}
+	return odds, nil
+}
+
+// nbaSeasonSQL returns a SQL expression deriving the season ID (e.g. "2024-25") from a date column
+func nbaSeasonSQL(dateColumn string) string {
+	startYear := fmt.Sprintf("(CASE WHEN month(CAST(%[1]s AS DATE)) >= 9 THEN year(CAST(%[1]s AS DATE)) ELSE year(CAST(%[1]s AS DATE)) - 1 END)", dateColumn)
+	return fmt.Sprintf("(%[1]s::VARCHAR || '-' || lpad(((%[1]s + 1) %% 100)::VARCHAR, 2, '0'))", startYear)
+}
+
+// GetNBADefenseVsPosition computes what every team allows per game to guards, forwards and centers
+// in a season, using player_boxscores and team_roster positions. lastXGames limits each defense
+// to its most recent games; 0 uses the whole season. Every defense gets a row per position,
+// with zeros where it allowed nothing.
+func GetNBADefenseVsPosition(db *sql.DB, seasonID string, lastXGames int) ([]models.NBADefenseVsPosition, error) {
+	query := `
+		WITH player_games AS (
+			SELECT
+				pb.GAME_ID AS game_id,
+				pb.game_date,
+				pb.OPPONENT AS defense,
+				LEFT(tr.POSITION, 1) AS position,
+				COALESCE(pb.points::DOUBLE, 0) AS points,
+				COALESCE(pb.reboundsTotal::DOUBLE, 0) AS rebounds,
+				COALESCE(pb.assists::DOUBLE, 0) AS assists,
+				COALESCE(pb.threePointersMade::DOUBLE, 0) AS threes
+			FROM nba_data.player_boxscores pb
+			JOIN nba_data.team_roster tr ON pb.player_id = tr.PLAYER_ID
+			WHERE ` + nbaSeasonSQL("pb.game_date") + ` = ?
+				AND LEFT(tr.POSITION, 1) IN ('G', 'F', 'C')
+		),
+		defense_games AS (
+			SELECT
+				defense,
+				game_id,
+				DENSE_RANK() OVER (PARTITION BY defense ORDER BY game_date DESC, game_id DESC) AS recency
+			FROM (SELECT DISTINCT defense, game_id, game_date FROM player_games)
+		),
+		games AS (
+			SELECT defense, COUNT(*) AS games
+			FROM defense_games
+			WHERE ? = 0 OR recency <= ?
+			GROUP BY defense
+		),
+		totals AS (
+			SELECT
+				pg.defense,
+				pg.position,
+				SUM(pg.points) AS points,
+				SUM(pg.rebounds) AS rebounds,
+				SUM(pg.assists) AS assists,
+				SUM(pg.threes) AS threes
+			FROM player_games pg
+			JOIN defense_games dg ON dg.defense = pg.defense AND dg.game_id = pg.game_id
+			WHERE ? = 0 OR dg.recency <= ?
+			GROUP BY pg.defense, pg.position
+		),
+		per_game AS (
+			SELECT
+				g.defense,
+				p.position,
+				g.games,
+				COALESCE(t.points, 0) / g.games AS points,
+				COALESCE(t.rebounds, 0) / g.games AS rebounds,
+				COALESCE(t.assists, 0) / g.games AS assists,
+				COALESCE(t.threes, 0) / g.games AS threes
+			FROM games g
+			-- every defense gets every position, so one that allowed nothing still ranks
+			CROSS JOIN (VALUES ('G'), ('F'), ('C')) AS p(position)
+			LEFT JOIN totals t ON t.defense = g.defense AND t.position = p.position
+		)
+		SELECT
+			defense,
+			position,
+			games,
+			points,
+			RANK() OVER (PARTITION BY position ORDER BY points DESC),
+			rebounds,
+			RANK() OVER (PARTITION BY position ORDER BY rebounds DESC),
+			assists,
+			RANK() OVER (PARTITION BY position ORDER BY assists DESC),
+			threes,
+			RANK() OVER (PARTITION BY position ORDER BY threes DESC)
+		FROM per_game
+		ORDER BY position, defense
+	`
+
+	rows, err := db.Query(query, seasonID, lastXGames, lastXGames, lastXGames, lastXGames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NBA defense vs position: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.NBADefenseVsPosition
+	for rows.Next() {
+		var s models.NBADefenseVsPosition
+		err := rows.Scan(
+			&s.TeamName, &s.Position, &s.Games,
+			&s.Points, &s.PointsRank,
+			&s.Rebounds, &s.ReboundsRank,
+			&s.Assists, &s.AssistsRank,
+			&s.ThreePointersMade, &s.ThreePointersMadeRank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan NBA defense vs position row: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over NBA defense vs position rows: %w", err)
+	}
+	return stats, nil
+}
+
+// GetNBASeasons lists the season IDs (e.g. "2024-25") present in the team stats and shot chart data, newest first
+func GetNBASeasons(db *sql.DB) ([]string, error) {
+	query := `
+		SELECT SEASON FROM nba_data.teams_advanced_stats
+		UNION
+		SELECT SEASON FROM nba_data.player_shotchart
+		ORDER BY SEASON DESC
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NBA seasons: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []string
+	for rows.Next() {
+		var season string
+		if err := rows.Scan(&season); err != nil {
+			return nil, fmt.Errorf("failed to scan NBA season row: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over NBA season rows: %w", err)
+	}
+
+	return seasons, nil
+}
+
+// GetNBACurrentSeason returns the most recent season ID in the team stats data
+func GetNBACurrentSeason(db *sql.DB) (string, error) {
+	query := `SELECT MAX(SEASON) FROM nba_data.teams_advanced_stats`
+
+	var season string
+	if err := db.QueryRow(query).Scan(&season); err != nil {
+		return "", fmt.Errorf("failed to query current NBA season: %w", err)
+	}
+	return season, nil
+}
+
+// GetNBATeamGameLines retrieves every box score line with minutes played for a team in its games for
+// a season. Lines are attributed by the box score's own opponent rather than the current roster, so
+// players traded in or out only bring along the games they played for the team.
+func GetNBATeamGameLines(db *sql.DB, teamID string, seasonID string) ([]models.NBAPlayerGameLine, error) {
+	query := `
+		WITH team_games AS (
+			SELECT DISTINCT GAME_ID
+			FROM nba_data.team_boxscores
+			WHERE TEAM_ID::VARCHAR = ?
+				AND ` + nbaSeasonSQL("GAME_DATE") + ` = ?
+		)
+		SELECT
+			pb.GAME_ID::VARCHAR,
+			pb.player_id::VARCHAR,
+			COALESCE(tr.PLAYER, ''),
+			COALESCE(pb.OPPONENT::VARCHAR, ''),
+			COALESCE(pb.points, 0)::DOUBLE,
+			COALESCE(pb.assists, 0)::DOUBLE,
+			COALESCE(pb.reboundsTotal, 0)::DOUBLE,
+			COALESCE(pb.minutes_per_game, 0)::DOUBLE
+		FROM nba_data.player_boxscores pb
+		JOIN team_games tg ON tg.GAME_ID = pb.GAME_ID
+		LEFT JOIN nba_data.team_roster tr ON tr.PLAYER_ID = pb.player_id
+		WHERE COALESCE(pb.minutes_per_game, 0) > 0
+		ORDER BY pb.GAME_ID
+	`
+
+	rows, err := db.Query(query, teamID, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team game lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []models.NBAPlayerGameLine
+	for rows.Next() {
+		var line models.NBAPlayerGameLine
+		var opponent string
+		err := rows.Scan(&line.GameID, &line.PlayerID, &line.PlayerName, &opponent, &line.Points, &line.Assists, &line.Rebounds, &line.Minutes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team game line row: %w", err)
+		}
+		// the other side's players have this team as their opponent
+		if nbaTeamID(opponent) == teamID {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over team game line rows: %w", err)
+	}
+
+	return lines, nil
+}
+
+// nbaTeamID resolves a team reference in any form the team registry accepts to its ID
+func nbaTeamID(s string) string {
+	if team, ok := identity.LookupNBATeam(s); ok {
+		return team.TeamID
+	}
+	return s
+}
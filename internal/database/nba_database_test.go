@@ -0,0 +1,72 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNBADefenseVsPosition(t *testing.T) {
+	db := openFixture(t,
+		`CREATE SCHEMA nba_data`,
+		`CREATE TABLE nba_data.team_roster (PLAYER_ID VARCHAR, POSITION VARCHAR)`,
+		`INSERT INTO nba_data.team_roster VALUES ('1', 'G'), ('2', 'F-C'), ('3', 'C'), ('4', '')`,
+		`CREATE TABLE nba_data.player_boxscores (GAME_ID VARCHAR, game_date DATE, OPPONENT VARCHAR, player_id VARCHAR, points INT, reboundsTotal INT, assists INT, threePointersMade INT)`,
+		`INSERT INTO nba_data.player_boxscores VALUES
+			('g0', '2024-05-01', 'BOS', '1', 50, 5, 5, 5),
+			('g1', '2024-11-01', 'BOS', '1', 20, 4, 6, 2),
+			('g1', '2024-11-01', 'BOS', '3', 10, 12, 1, 0),
+			('g1', '2024-11-01', 'BOS', '4', 40, 0, 0, 0),
+			('g2', '2024-11-05', 'BOS', '1', 30, 2, 8, 4),
+			('g2', '2024-11-05', 'BOS', '2', 8, 6, 2, NULL),
+			('g3', '2024-11-02', 'NYK', '1', 10, 3, 4, 1),
+			('g3', '2024-11-02', 'NYK', '3', 14, 9, 2, 0)`,
+	)
+
+	type allowed struct {
+		games      int
+		points     float64
+		pointsRank int
+	}
+	report := func(lastXGames int) map[string]allowed {
+		stats, err := GetNBADefenseVsPosition(db, "2024-25", lastXGames)
+		require.NoError(t, err)
+		got := map[string]allowed{}
+		for _, s := range stats {
+			got[s.TeamName+" "+s.Position] = allowed{s.Games, s.Points, s.PointsRank}
+		}
+		return got
+	}
+
+	// the May game is last season and the player without a position is left out
+	assert.Equal(t, map[string]allowed{
+		"BOS G": {2, 25, 1},
+		"BOS F": {2, 4, 1},
+		"BOS C": {2, 5, 2},
+		"NYK G": {1, 10, 2},
+		"NYK F": {1, 0, 2},
+		"NYK C": {1, 14, 1},
+	}, report(0))
+
+	// only Boston's latest game counts, so its centers allowed nothing and rank last
+	assert.Equal(t, map[string]allowed{
+		"BOS G": {1, 30, 1},
+		"BOS F": {1, 8, 1},
+		"BOS C": {1, 0, 2},
+		"NYK G": {1, 10, 2},
+		"NYK F": {1, 0, 2},
+		"NYK C": {1, 14, 1},
+	}, report(1))
+
+	stats, err := GetNBADefenseVsPosition(db, "2024-25", 0)
+	require.NoError(t, err)
+	for _, s := range stats {
+		if s.TeamName == "BOS" && s.Position == "G" {
+			assert.Equal(t, 3.0, s.Rebounds)
+			assert.Equal(t, 7.0, s.Assists)
+			assert.Equal(t, 3.0, s.ThreePointersMade)
+			assert.Equal(t, 1, s.ThreePointersMadeRank)
+		}
+	}
+}
@@ -4,21 +4,22 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"sports_api/internal/identity"
 	"sports_api/internal/models"
+	"strings"
 )
 
 // NFL Database operations
 
-// GetPlayersByTeam retrieves all players for a given NFL team
-func GetPlayersByTeam(db *sql.DB, teamName string) ([]models.NFLPlayer, error) {
+// GetPlayersByTeam retrieves all players for the NFL team with the given team ID
+func GetPlayersByTeam(db *sql.DB, teamID string) ([]models.NFLPlayer, error) {
 	query := `
 		SELECT player_name, position
 		FROM nfl_data.nfl_roster_db 
-		WHERE team_name = ? 
+		WHERE team_id = ? 
 		ORDER BY player_name
 	`
-	fmt.Printf("Getting players by team: %s\n", teamName)
-	rows, err := db.Query(query, teamName)
+	rows, err := db.Query(query, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query players: %w", err)
 	}
@@ -72,9 +73,86 @@ func GetAllTeams(db *sql.DB) ([]string, error) {
 	return teams, nil
 }
 
-func GetPlayerRushingStats(db *sql.DB, playerName string) (models.NFLPlayerRushingStats, error) {
+// GetNFLTeamIDByName retrieves an NFL team's ID by its roster team name
+func GetNFLTeamIDByName(db *sql.DB, teamName string) (string, error) {
+	query := `SELECT team_id FROM nfl_data.nfl_roster_db WHERE team_name = ? LIMIT 1`
+
+	var teamID string
+	err := db.QueryRow(query, teamName).Scan(&teamID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get team ID: %w", err)
+	}
+
+	return teamID, nil
+}
+
+// GetNFLTeamNameByID retrieves an NFL team's roster team name by ID
+func GetNFLTeamNameByID(db *sql.DB, teamID string) (string, error) {
+	query := `SELECT team_name FROM nfl_data.nfl_roster_db WHERE team_id = ? LIMIT 1`
+
+	var teamName string
+	err := db.QueryRow(query, teamID).Scan(&teamName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get team name: %w", err)
+	}
+
+	return teamName, nil
+}
+
+// GetNFLPasserIDByName retrieves an NFL player's ID by roster name or, for quarterbacks, by the
+// abbreviated name play-by-play data uses ("P.Mahomes")
+func GetNFLPasserIDByName(db *sql.DB, playerName string) (string, error) {
 	query := `
-		SELECT 
+		SELECT player_id
+		FROM nfl_data.nfl_roster_db
+		WHERE player_name = ?
+			OR ("position" = 'QB' AND ` + nflPBPNameSQL("player_name") + ` = ?)
+		ORDER BY player_name = ? DESC
+		LIMIT 1
+	`
+
+	var playerID string
+	err := db.QueryRow(query, playerName, playerName, playerName).Scan(&playerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get player ID: %w", err)
+	}
+
+	return playerID, nil
+}
+
+// GetNFLPlayerIDByName retrieves an NFL player's ID by name
+func GetNFLPlayerIDByName(db *sql.DB, playerName string) (string, error) {
+	query := `SELECT player_id FROM nfl_data.nfl_roster_db WHERE player_name = ? LIMIT 1`
+
+	var playerID string
+	err := db.QueryRow(query, playerName).Scan(&playerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get player ID: %w", err)
+	}
+
+	return playerID, nil
+}
+
+// GetNFLPlayerNameByID retrieves an NFL player's display name by ID
+func GetNFLPlayerNameByID(db *sql.DB, playerID string) (string, error) {
+	query := `SELECT player_name FROM nfl_data.nfl_roster_db WHERE player_id = ? LIMIT 1`
+
+	var playerName string
+	err := db.QueryRow(query, playerID).Scan(&playerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get player name: %w", err)
+	}
+
+	return playerName, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+const nflRushingStatsColumns = `
+			season::int AS season,
 			avgGain, longRushing, netTotalYards, netYardsPerGame,
 			rushingAttempts, rushingBigPlays, rushingFirstDowns, rushingFumbles,
 			rushingFumblesLost, rushingTouchdowns, rushingYards, rushingYardsPerGame,
@@ -82,13 +160,12 @@ func GetPlayerRushingStats(db *sql.DB, playerName string) (models.NFLPlayerRushi
 			totalPointsPerGame, totalTouchdowns, totalYards, totalYardsFromScrimmage,
 			twoPointRushConvs, twoPtRush, twoPtRushAttempts,
 			yardsFromScrimmagePerGame, yardsPerGame, yardsPerRushAttempt,
-			player_name
-		FROM nfl_data.nfl_rushing_db
-		WHERE player_name = ?
-	`
+			player_name`
 
+func scanRushingStats(row rowScanner) (models.NFLPlayerRushingStats, error) {
 	var playerRushingStats models.NFLPlayerRushingStats
-	err := db.QueryRow(query, playerName).Scan(
+	err := row.Scan(
+		&playerRushingStats.Season,
 		&playerRushingStats.AvgGain,
 		&playerRushingStats.LongRushing,
 		&playerRushingStats.NetTotalYards,
@@ -117,9 +194,25 @@ func GetPlayerRushingStats(db *sql.DB, playerName string) (models.NFLPlayerRushi
 		&playerRushingStats.YardsPerRushAttempt,
 		&playerRushingStats.PlayerName,
 	)
+	return playerRushingStats, err
+}
+
+// GetPlayerRushingStats retrieves a player's rushing stats by player ID for a season, or their most recent
+// season when season is 0
+func GetPlayerRushingStats(db *sql.DB, playerID string, season int) (models.NFLPlayerRushingStats, error) {
+	query := `
+		SELECT ` + nflRushingStatsColumns + `
+		FROM nfl_data.nfl_rushing_db
+		WHERE player_id::VARCHAR = ?
+			AND (? = 0 OR season = ?)
+		ORDER BY season DESC
+		LIMIT 1
+	`
+
+	playerRushingStats, err := scanRushingStats(db.QueryRow(query, playerID, season, season))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.NFLPlayerRushingStats{}, fmt.Errorf("no rushing stats found for player: %s", playerName)
+			return models.NFLPlayerRushingStats{}, fmt.Errorf("no rushing stats found for player ID: %s", playerID)
 		}
 		return models.NFLPlayerRushingStats{}, fmt.Errorf("failed to scan rushing stats row: %w", err)
 	}
@@ -127,9 +220,38 @@ func GetPlayerRushingStats(db *sql.DB, playerName string) (models.NFLPlayerRushi
 	return playerRushingStats, nil
 }
 
-func GetPlayerPassingStats(db *sql.DB, playerName string) (models.NFLPlayerPassingStats, error) {
+// GetPlayerRushingCareer retrieves one row of a player's rushing stats per season by player ID, oldest first
+func GetPlayerRushingCareer(db *sql.DB, playerID string) ([]models.NFLPlayerRushingStats, error) {
 	query := `
-		SELECT 
+		SELECT ` + nflRushingStatsColumns + `
+		FROM nfl_data.nfl_rushing_db
+		WHERE player_id::VARCHAR = ?
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY season) = 1
+		ORDER BY season
+	`
+
+	rows, err := db.Query(query, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rushing career: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []models.NFLPlayerRushingStats
+	for rows.Next() {
+		stats, err := scanRushingStats(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rushing stats row: %w", err)
+		}
+		seasons = append(seasons, stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rushing career rows: %w", err)
+	}
+	return seasons, nil
+}
+
+const nflPassingStatsColumns = `
+			season::int AS season,
 			avgGain,
 			completionPct,
 			completions,
@@ -143,13 +265,12 @@ func GetPlayerPassingStats(db *sql.DB, playerName string) (models.NFLPlayerPassi
 			passingAttempts,
 			passingYards,
 			totalOffensivePlays,
-			player_name
-		FROM nfl_data.nfl_passing_db
-		WHERE player_name = ?
-	`
+			player_name`
 
+func scanPassingStats(row rowScanner) (models.NFLPlayerPassingStats, error) {
 	var playerPassingStats models.NFLPlayerPassingStats
-	err := db.QueryRow(query, playerName).Scan(
+	err := row.Scan(
+		&playerPassingStats.Season,                 // season
 		&playerPassingStats.AvgGain,                // avgGain
 		&playerPassingStats.CompletionPct,          // completionPct
 		&playerPassingStats.Completions,            // completions
@@ -165,9 +286,25 @@ func GetPlayerPassingStats(db *sql.DB, playerName string) (models.NFLPlayerPassi
 		&playerPassingStats.TotalOffensivePlays,    // totalOffensivePlays
 		&playerPassingStats.PlayerName,             // player_name
 	)
+	return playerPassingStats, err
+}
+
+// GetPlayerPassingStats retrieves a player's passing stats by player ID for a season, or their most recent
+// season when season is 0
+func GetPlayerPassingStats(db *sql.DB, playerID string, season int) (models.NFLPlayerPassingStats, error) {
+	query := `
+		SELECT ` + nflPassingStatsColumns + `
+		FROM nfl_data.nfl_passing_db
+		WHERE player_id::VARCHAR = ?
+			AND (? = 0 OR season = ?)
+		ORDER BY season DESC
+		LIMIT 1
+	`
+
+	playerPassingStats, err := scanPassingStats(db.QueryRow(query, playerID, season, season))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.NFLPlayerPassingStats{}, fmt.Errorf("no passing stats found for player: %s", playerName)
+			return models.NFLPlayerPassingStats{}, fmt.Errorf("no passing stats found for player ID: %s", playerID)
 		}
 		return models.NFLPlayerPassingStats{}, fmt.Errorf("failed to scan passing stats row: %w", err)
 	}
@@ -175,9 +312,38 @@ func GetPlayerPassingStats(db *sql.DB, playerName string) (models.NFLPlayerPassi
 	return playerPassingStats, nil
 }
 
-func GetPlayerReceivingStats(db *sql.DB, playerName string) (models.NFLPlayerReceivingStats, error) {
+// GetPlayerPassingCareer retrieves one row of a player's passing stats per season by player ID, oldest first
+func GetPlayerPassingCareer(db *sql.DB, playerID string) ([]models.NFLPlayerPassingStats, error) {
 	query := `
-		SELECT 
+		SELECT ` + nflPassingStatsColumns + `
+		FROM nfl_data.nfl_passing_db
+		WHERE player_id::VARCHAR = ?
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY season) = 1
+		ORDER BY season
+	`
+
+	rows, err := db.Query(query, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query passing career: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []models.NFLPlayerPassingStats
+	for rows.Next() {
+		stats, err := scanPassingStats(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan passing stats row: %w", err)
+		}
+		seasons = append(seasons, stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over passing career rows: %w", err)
+	}
+	return seasons, nil
+}
+
+const nflReceivingStatsColumns = `
+			season::int AS season,
 			avgGain,
 			longReception,
 			netTotalYards,
@@ -205,13 +371,12 @@ func GetPlayerReceivingStats(db *sql.DB, playerName string) (models.NFLPlayerRec
 			yardsFromScrimmagePerGame,
 			yardsPerGame,
 			yardsPerReception,
-			player_name
-		FROM nfl_data.nfl_receiving_db
-		WHERE player_name = ?
-	`
+			player_name`
 
+func scanReceivingStats(row rowScanner) (models.NFLPlayerReceivingStats, error) {
 	var playerReceivingStats models.NFLPlayerReceivingStats
-	err := db.QueryRow(query, playerName).Scan(
+	err := row.Scan(
+		&playerReceivingStats.Season,
 		&playerReceivingStats.AvgGain,
 		&playerReceivingStats.LongReception,
 		&playerReceivingStats.NetTotalYards,
@@ -241,9 +406,25 @@ func GetPlayerReceivingStats(db *sql.DB, playerName string) (models.NFLPlayerRec
 		&playerReceivingStats.YardsPerReception,
 		&playerReceivingStats.PlayerName,
 	)
+	return playerReceivingStats, err
+}
+
+// GetPlayerReceivingStats retrieves a player's receiving stats by player ID for a season, or their most recent
+// season when season is 0
+func GetPlayerReceivingStats(db *sql.DB, playerID string, season int) (models.NFLPlayerReceivingStats, error) {
+	query := `
+		SELECT ` + nflReceivingStatsColumns + `
+		FROM nfl_data.nfl_receiving_db
+		WHERE player_id::VARCHAR = ?
+			AND (? = 0 OR season = ?)
+		ORDER BY season DESC
+		LIMIT 1
+	`
+
+	playerReceivingStats, err := scanReceivingStats(db.QueryRow(query, playerID, season, season))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.NFLPlayerReceivingStats{}, fmt.Errorf("no receiving stats found for player: %s", playerName)
+			return models.NFLPlayerReceivingStats{}, fmt.Errorf("no receiving stats found for player ID: %s", playerID)
 		}
 		return models.NFLPlayerReceivingStats{}, fmt.Errorf("failed to scan receiving stats row: %w", err)
 	}
@@ -251,32 +432,213 @@ func GetPlayerReceivingStats(db *sql.DB, playerName string) (models.NFLPlayerRec
 	return playerReceivingStats, nil
 }
 
-func GetEvents(db *sql.DB, eventType string) (models.NFLEvent, error) {
+// GetPlayerReceivingCareer retrieves one row of a player's receiving stats per season by player ID, oldest first
+func GetPlayerReceivingCareer(db *sql.DB, playerID string) ([]models.NFLPlayerReceivingStats, error) {
 	query := `
-		SELECT 
-			event_id,
-			event_date,
-			event_week
-		FROM nfl_data.nfl_game_events_db
+		SELECT ` + nflReceivingStatsColumns + `
+		FROM nfl_data.nfl_receiving_db
+		WHERE player_id::VARCHAR = ?
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY season) = 1
+		ORDER BY season
 	`
-	var events models.NFLEvent
-	err := db.QueryRow(query).Scan(
-		&events.EventID,
-		&events.EventDate,
-		&events.EventWeek,
+
+	rows, err := db.Query(query, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query receiving career: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []models.NFLPlayerReceivingStats
+	for rows.Next() {
+		stats, err := scanReceivingStats(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan receiving stats row: %w", err)
+		}
+		seasons = append(seasons, stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over receiving career rows: %w", err)
+	}
+	return seasons, nil
+}
+
+// NFLEventFilter narrows the schedule. Zero values match every event; Team matches either side.
+type NFLEventFilter struct {
+	Season   int
+	WeekFrom int
+	WeekTo   int
+	Team     string
+}
+
+// GetEvents retrieves scheduled and played games from nfl_game_events_db in kickoff order with
+// their final scores (NULL until the game is final) and the prop markets offered in nfl_prop_odds.
+// Seasons are derived from the kickoff date, so January and February games count toward the
+// previous year's season.
+func GetEvents(db *sql.DB, filter NFLEventFilter) ([]models.NFLEvent, error) {
+	query := `
+		WITH events AS (
+			SELECT
+				event_id,
+				CAST(event_date AS TIMESTAMP) AS kickoff,
+				event_week,
+				CASE
+					WHEN month(CAST(event_date AS DATE)) >= 3 THEN year(CAST(event_date AS DATE))
+					ELSE year(CAST(event_date AS DATE)) - 1
+				END AS season,
+				home_team,
+				away_team,
+				home_score,
+				away_score
+			FROM nfl_data.nfl_game_events_db
+		),
+		markets AS (
+			SELECT event_id, string_agg(DISTINCT market, ',' ORDER BY market) AS markets
+			FROM nfl_data.nfl_prop_odds
+			GROUP BY event_id
+		)
+		SELECT
+			e.event_id::VARCHAR,
+			CAST(e.kickoff AS DATE)::VARCHAR,
+			e.kickoff,
+			COALESCE(e.event_week, 0)::INTEGER,
+			e.season::INTEGER,
+			COALESCE(e.home_team, ''),
+			COALESCE(e.away_team, ''),
+			e.home_score::INTEGER,
+			e.away_score::INTEGER,
+			COALESCE(m.markets, '')
+		FROM events e
+		LEFT JOIN markets m ON m.event_id = e.event_id
+		WHERE (? = 0 OR e.season = ?)
+			AND (? = 0 OR e.event_week >= ?)
+			AND (? = 0 OR e.event_week <= ?)
+			AND (? = '' OR e.home_team = ? OR e.away_team = ?)
+		ORDER BY e.kickoff, e.event_id
+	`
+
+	rows, err := db.Query(query,
+		filter.Season, filter.Season,
+		filter.WeekFrom, filter.WeekFrom,
+		filter.WeekTo, filter.WeekTo,
+		filter.Team, filter.Team, filter.Team,
 	)
 	if err != nil {
-		return models.NFLEvent{}, fmt.Errorf("failed to scan event row: %w", err)
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.NFLEvent{}
+	for rows.Next() {
+		var event models.NFLEvent
+		var kickoff sql.NullTime
+		var homeScore, awayScore sql.NullInt64
+		var markets string
+		err := rows.Scan(
+			&event.EventID,
+			&event.EventDate,
+			&kickoff,
+			&event.EventWeek,
+			&event.Season,
+			&event.HomeTeam,
+			&event.AwayTeam,
+			&homeScore,
+			&awayScore,
+			&markets,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event row: %w", err)
+		}
+		if kickoff.Valid {
+			event.Kickoff = &kickoff.Time
+		}
+		event.Status = "scheduled"
+		if homeScore.Valid && awayScore.Valid {
+			home, away := int(homeScore.Int64), int(awayScore.Int64)
+			event.HomeScore, event.AwayScore = &home, &away
+			event.Status = "final"
+		}
+		event.PropMarkets = []string{}
+		if markets != "" {
+			event.PropMarkets = strings.Split(markets, ",")
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over event rows: %w", err)
 	}
 	return events, nil
 }
 
-func GetRushingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats], error) {
-	slog.Debug("Getting rushing game stats for player: %s", playerName)
+// NFLGamelogFilter narrows a player's gamelog. Zero values match every game; HomeAway is "home" or "away".
+type NFLGamelogFilter struct {
+	Season   int
+	WeekFrom int
+	WeekTo   int
+	Opponent string
+	HomeAway string
+}
+
+// nflTeamNamesSQL is a derived table gt(team_id, team_name) mapping the team abbreviations used by the
+// per-game tables (snap counts, play-by-play) to the full names used by the game events
+var nflTeamNamesSQL = func() string {
+	values := []string{}
+	for _, team := range identity.NFLTeams() {
+		values = append(values, fmt.Sprintf("('%s', '%s')", team.TeamID, strings.ReplaceAll(team.TeamName, "'", "''")))
+	}
+	return "(VALUES " + strings.Join(values, ", ") + ") AS gt(team_id, team_name)"
+}()
+
+// nflGamelogFilterSQL joins the gamelog (aliased gl) and its snap counts (aliased ps) to the team the
+// player played for in that game and to the game events, deriving the opponent and home/away, and
+// applies the filter. Games are de-duplicated by game_id and ordered by date.
+var nflGamelogFilterSQL = `
+		LEFT JOIN ` + nflTeamNamesSQL + ` ON gt.team_id = ps.team
+		LEFT JOIN nfl_data.nfl_game_events_db ev ON ev.event_id = gl.game_id
+		WHERE gl.player_id::VARCHAR = ?
+			AND (? = 0 OR gl.season = ?)
+			AND (? = 0 OR gl.game_week >= ?)
+			AND (? = 0 OR gl.game_week <= ?)
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY gl.game_id ORDER BY gl.game_date) = 1
+	) games
+	WHERE (? = '' OR opponent = ?)
+		AND (? = '' OR home_away = ?)
+	ORDER BY game_date
+`
+
+// nflGamelogOpponentSQL selects the opponent and home/away columns used by nflGamelogFilterSQL. Both
+// are empty when the game's team or event is unknown.
+const nflGamelogOpponentSQL = `
+			CASE
+				WHEN ev.home_team = gt.team_name THEN ev.away_team
+				WHEN ev.away_team = gt.team_name THEN ev.home_team
+				ELSE ''
+			END AS opponent,
+			CASE
+				WHEN ev.home_team = gt.team_name THEN 'home'
+				WHEN ev.away_team = gt.team_name THEN 'away'
+				ELSE ''
+			END AS home_away`
+
+func (f NFLGamelogFilter) args(playerID string) []any {
+	return []any{
+		playerID,
+		f.Season, f.Season,
+		f.WeekFrom, f.WeekFrom,
+		f.WeekTo, f.WeekTo,
+		f.Opponent, f.Opponent,
+		f.HomeAway, f.HomeAway,
+	}
+}
+
+// GetRushingGameStats retrieves a player's rushing and receiving gamelog by player ID, narrowed by filter
+func GetRushingGameStats(db *sql.DB, playerID string, filter NFLGamelogFilter) (models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats, models.NFLRushingReceivingTotals], error) {
+	slog.Debug("Getting rushing game stats", "player_id", playerID)
 	query := `
-		SELECT DISTINCT
+	SELECT * FROM (
+		SELECT
 			gl.game_id,
 			gl.player_name,
+			gl.season::int as season,
 			COALESCE(gl.rushingAttempts::int, 0) as rushingAttempts,
 			COALESCE(gl.rushingYards::int, 0) as rushingYards,
 			COALESCE(gl.rushingTouchdowns::int, 0) as rushingTouchdowns,
@@ -292,18 +654,16 @@ func GetRushingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelog
 			gl.game_date,
 			gl.game_week,
 			COALESCE(ps.offense_snaps::int, 0) as offense_snaps,
-			COALESCE(ps.offense_snap_pct, 0) as offense_snap_pct,
+			COALESCE(ps.offense_snap_pct, 0) as offense_snap_pct,` + nflGamelogOpponentSQL + `
 		FROM nfl_data.nfl_player_gamelog gl
 		JOIN nfl_data.nfl_player_snap_counts ps 
 				ON gl.player_id = ps.player_id 
 				AND gl.season = ps.season 
-				AND gl.game_week = ps.game_week
-		WHERE gl.player_name = ?
-	`
+				AND gl.game_week = ps.game_week` + nflGamelogFilterSQL
 
-	rows, err := db.Query(query, playerName)
+	rows, err := db.Query(query, filter.args(playerID)...)
 	if err != nil {
-		return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats]{}, fmt.Errorf("failed to query gamelog stats: %w", err)
+		return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats, models.NFLRushingReceivingTotals]{}, fmt.Errorf("failed to query gamelog stats: %w", err)
 	}
 	defer rows.Close()
 	var games []models.NFLPlayerRushingReceivingGamelogStats
@@ -312,6 +672,7 @@ func GetRushingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelog
 		err := rows.Scan(
 			&game.GameID,
 			&game.PlayerName,
+			&game.Season,
 			&game.RushingAttempts,
 			&game.RushingYards,
 			&game.RushingTouchdowns,
@@ -328,32 +689,41 @@ func GetRushingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelog
 			&game.GameWeek,
 			&game.OffenseSnaps,
 			&game.OffenseSnapPct,
+			&game.Opponent,
+			&game.HomeAway,
 		)
 		if err != nil {
-			return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats]{}, fmt.Errorf("failed to scan gamelog stats row: %w", err)
+			return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats, models.NFLRushingReceivingTotals]{}, fmt.Errorf("failed to scan gamelog stats row: %w", err)
+		}
+		if game.RushingAttempts > 0 {
+			game.YardsPerRushAttempt = float64(game.RushingYards) / float64(game.RushingAttempts)
 		}
 		games = append(games, game)
 	}
 	if err = rows.Err(); err != nil {
-		return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats]{}, fmt.Errorf("error iterating over gamelog stats rows: %w", err)
+		return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats, models.NFLRushingReceivingTotals]{}, fmt.Errorf("error iterating over gamelog stats rows: %w", err)
 	}
-	return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats]{
-		Games: games,
+	return models.NFLPlayerGamelogCollection[models.NFLPlayerRushingReceivingGamelogStats, models.NFLRushingReceivingTotals]{
+		Games:  games,
+		Totals: seasonTotals(games, func(g models.NFLPlayerRushingReceivingGamelogStats) int { return g.Season }, rushingReceivingTotals),
 	}, nil
 }
 
-func GetPassingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats], error) {
-	slog.Debug("Getting passing game stats for player: %s", playerName)
+// GetPassingGameStats retrieves a player's passing gamelog by player ID, narrowed by filter
+func GetPassingGameStats(db *sql.DB, playerID string, filter NFLGamelogFilter) (models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats, models.NFLPassingTotals], error) {
+	slog.Debug("Getting passing game stats", "player_id", playerID)
 	query := `
-		SELECT DISTINCT
+	SELECT * FROM (
+		SELECT
 			gl.game_id,
 			gl.player_name,
+			gl.season::int                                AS season,
 			gl.game_date,
 			gl.game_week,
 			COALESCE(ps.offense_snaps::int, 0)            AS offense_snaps,
 			COALESCE(ps.offense_snap_pct, 0)              AS offense_snap_pct,
 			COALESCE(gl.rushingAttempts::int, 0)          AS rushingAttempts,
-			COALESCE(gl.yardsPerRushAttempt::int, 0)      AS yardsPerRushAttempt,
+			COALESCE(gl.yardsPerRushAttempt::double, 0)   AS yardsPerRushAttempt,
 			COALESCE(gl.rushingYards::int, 0)             AS rushingYards,
 			COALESCE(gl.rushingTouchdowns::int, 0)        AS rushingTouchdowns,
 			COALESCE(gl.longRushing::int, 0)              AS longRushing,
@@ -363,19 +733,17 @@ func GetPassingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelog
 			COALESCE(gl.passingTouchdowns::int, 0)        AS passingTouchdowns,
 			COALESCE(gl.interceptions::int, 0)            AS interceptions,
 			COALESCE(gl.QBRating::double, 0)              AS QBRating,
-			COALESCE(gl.yardsPerPassAttempt::double, 0)   AS yardsPerPassAttempt
+			COALESCE(gl.yardsPerPassAttempt::double, 0)   AS yardsPerPassAttempt,` + nflGamelogOpponentSQL + `
 		FROM 
 			nfl_data.nfl_qb_gamelog gl
 			JOIN nfl_data.nfl_player_snap_counts ps 
 				ON gl.player_id = ps.player_id 
 				AND gl.season = ps.season 
-				AND gl.game_week = ps.game_week
-		WHERE gl.player_name = ?
-	`
+				AND gl.game_week = ps.game_week` + nflGamelogFilterSQL
 
-	rows, err := db.Query(query, playerName)
+	rows, err := db.Query(query, filter.args(playerID)...)
 	if err != nil {
-		return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats]{}, fmt.Errorf("failed to query gamelog stats: %w", err)
+		return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats, models.NFLPassingTotals]{}, fmt.Errorf("failed to query gamelog stats: %w", err)
 	}
 	defer rows.Close()
 	var games []models.NFLPlayerPassingGamelogStats
@@ -384,6 +752,7 @@ func GetPassingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelog
 		err := rows.Scan(
 			&game.GameID,
 			&game.PlayerName,
+			&game.Season,
 			&game.GameDate,
 			&game.GameWeek,
 			&game.OffenseSnaps,
@@ -400,20 +769,102 @@ func GetPassingGameStats(db *sql.DB, playerName string) (models.NFLPlayerGamelog
 			&game.Interceptions,
 			&game.QBRating,
 			&game.YardsPerPassAttempt,
+			&game.Opponent,
+			&game.HomeAway,
 		)
 		if err != nil {
-			return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats]{}, fmt.Errorf("failed to scan gamelog stats row: %w", err)
+			return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats, models.NFLPassingTotals]{}, fmt.Errorf("failed to scan gamelog stats row: %w", err)
 		}
 		games = append(games, game)
 	}
 	if err = rows.Err(); err != nil {
-		return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats]{}, fmt.Errorf("error iterating over gamelog stats rows: %w", err)
+		return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats, models.NFLPassingTotals]{}, fmt.Errorf("error iterating over gamelog stats rows: %w", err)
 	}
-	return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats]{
-		Games: games,
+	return models.NFLPlayerGamelogCollection[models.NFLPlayerPassingGamelogStats, models.NFLPassingTotals]{
+		Games:  games,
+		Totals: seasonTotals(games, func(g models.NFLPlayerPassingGamelogStats) int { return g.Season }, passingTotals),
 	}, nil
 }
 
+// seasonTotals totals date-ordered gamelog rows once per season, oldest season first
+func seasonTotals[T any, S any](games []T, season func(T) int, total func([]T) S) []S {
+	totals := []S{}
+	for start := 0; start < len(games); {
+		end := start + 1
+		for end < len(games) && season(games[end]) == season(games[start]) {
+			end++
+		}
+		totals = append(totals, total(games[start:end]))
+		start = end
+	}
+	return totals
+}
+
+// rushingReceivingTotals sums one season's gamelog rows, recomputing the per-attempt rates from the totals
+func rushingReceivingTotals(games []models.NFLPlayerRushingReceivingGamelogStats) models.NFLRushingReceivingTotals {
+	var t models.NFLRushingReceivingTotals
+	for _, g := range games {
+		t.Season = g.Season
+		t.Games++
+		t.RushingAttempts += g.RushingAttempts
+		t.RushingYards += g.RushingYards
+		t.RushingTouchdowns += g.RushingTouchdowns
+		t.LongRushing = max(t.LongRushing, g.LongRushing)
+		t.Receptions += g.Receptions
+		t.ReceivingTargets += g.ReceivingTargets
+		t.ReceivingYards += g.ReceivingYards
+		t.ReceivingTouchdowns += g.ReceivingTouchdowns
+		t.LongReception = max(t.LongReception, g.LongReception)
+		t.Fumbles += g.Fumbles
+		t.FumblesLost += g.FumblesLost
+		t.OffenseSnaps += g.OffenseSnaps
+		t.OffenseSnapPct += g.OffenseSnapPct
+	}
+	if t.RushingAttempts > 0 {
+		t.YardsPerRushAttempt = float64(t.RushingYards) / float64(t.RushingAttempts)
+	}
+	if t.Receptions > 0 {
+		t.YardsPerReception = float64(t.ReceivingYards) / float64(t.Receptions)
+	}
+	if t.Games > 0 {
+		t.OffenseSnapPct /= float64(t.Games)
+	}
+	return t
+}
+
+// passingTotals sums one season's gamelog rows, recomputing the per-attempt rates from the totals
+func passingTotals(games []models.NFLPlayerPassingGamelogStats) models.NFLPassingTotals {
+	var t models.NFLPassingTotals
+	for _, g := range games {
+		t.Season = g.Season
+		t.Games++
+		t.RushingAttempts += g.RushingAttempts
+		t.RushingYards += g.RushingYards
+		t.RushingTouchdowns += g.RushingTouchdowns
+		t.LongRushing = max(t.LongRushing, g.LongRushing)
+		t.PassingAttempts += g.PassingAttempts
+		t.PassingCompletions += g.PassingCompletions
+		t.PassingYards += g.PassingYards
+		t.PassingTouchdowns += g.PassingTouchdowns
+		t.Interceptions += g.Interceptions
+		t.QBRating += g.QBRating
+		t.OffenseSnaps += g.OffenseSnaps
+		t.OffenseSnapPct += g.OffenseSnapPct
+	}
+	if t.RushingAttempts > 0 {
+		t.YardsPerRushAttempt = float64(t.RushingYards) / float64(t.RushingAttempts)
+	}
+	if t.PassingAttempts > 0 {
+		t.CompletionPct = float64(t.PassingCompletions) / float64(t.PassingAttempts) * 100
+		t.YardsPerPassAttempt = float64(t.PassingYards) / float64(t.PassingAttempts)
+	}
+	if t.Games > 0 {
+		t.QBRating /= float64(t.Games)
+		t.OffenseSnapPct /= float64(t.Games)
+	}
+	return t
+}
+
 func GetNFLTeamDefenseStats(db *sql.DB, teamName string) (models.NFLTeamDefenseStats, error) {
 	query := `
 		SELECT
@@ -626,23 +1077,48 @@ func GetNFLTeamOffenseStats(db *sql.DB, teamName string) (models.NFLTeamOffenseS
 	return teamOffenseStats, nil
 }
 
-func GetNFLPassingPBPStats(db *sql.DB, playerName string, season int) ([]models.NFLPassingPBPStats, error) {
+// nflPBPNameSQL returns a SQL expression abbreviating the full player name in nameColumn the way
+// play-by-play data names passers and receivers: "Travis Kelce Jr." becomes "T.Kelce"
+func nflPBPNameSQL(nameColumn string) string {
+	base := `regexp_replace(` + nameColumn + `, '\s+(Jr\.?|Sr\.?|II|III|IV|V)$', '')`
+	return `(LEFT(` + base + `, 1) || '.' || regexp_extract(` + base + `, '^\S+\s+(.*)$', 1))`
+}
+
+// GetNFLPassingPBPStats retrieves a passer's plays for a season by player ID. Play-by-play data
+// carries no roster player IDs, so plays are matched on the abbreviated passer name and the team
+// (posteam) the player played for that week, as in GetNFLReceiverUsage.
+func GetNFLPassingPBPStats(db *sql.DB, playerID string, season int) ([]models.NFLPassingPBPStats, error) {
 	query := `
-		SELECT 
-			week,
-			opponent,
-			complete_pass, 
-			interception, 
-			air_yards, 
-			pass_location, 
-			pass_length 
-		FROM nfl_data.nfl_pbp_qb_data 
-		WHERE passer = ?
-		AND season = ?
+		WITH player AS (
+			SELECT player_id, team_id, ` + nflPBPNameSQL("player_name") + ` AS pbp_name
+			FROM nfl_data.nfl_roster_db
+			WHERE player_id::VARCHAR = ?
+			LIMIT 1
+		)
+		SELECT
+			q.week,
+			q.opponent,
+			q.complete_pass,
+			q.interception,
+			q.air_yards,
+			q.pass_location,
+			q.pass_length
+		FROM nfl_data.nfl_pbp_qb_data q
+		JOIN player p ON q.passer = p.pbp_name
+		LEFT JOIN nfl_data.nfl_player_snap_counts ps
+			ON ps.player_id = p.player_id
+			AND ps.season = q.season
+			AND ps.game_week = q.week
+		WHERE q.season = ?
+			AND q.posteam = COALESCE(ps.team, p.team_id)
+			-- sacks and spikes have no target
+			AND q.pass_location IS NOT NULL
+			AND q.pass_length IS NOT NULL
+			AND q.air_yards IS NOT NULL
 	`
 
 	var passingPBPStats []models.NFLPassingPBPStats
-	rows, err := db.Query(query, playerName, season)
+	rows, err := db.Query(query, playerID, season)
 	if err != nil {
 		return []models.NFLPassingPBPStats{}, fmt.Errorf("failed to query passing PBP stats: %w", err)
 	}
@@ -704,3 +1180,614 @@ func GetNFLPropOdds(db *sql.DB, name string, market string) ([]models.Odds, erro
 
 	return odds, nil
 }
+
+// GetNFLTeamDefenseRankings retrieves defensive stats for every team. String-typed
+// percentages and ranks from the source tables are cast to numbers; a rank the
+// source lacks stays NULL instead of reading as first place.
+func GetNFLTeamDefenseRankings(db *sql.DB) ([]models.NFLTeamDefenseStatsV2, error) {
+	query := `
+		SELECT
+			tds.team_name,
+			COALESCE(TRY_CAST(REPLACE(sumer."sack_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."sack_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(sumer."epa/play"::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sumer."epa/play_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(REPLACE(sumer."success_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."success_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(tds.rush_epa_allowed::DOUBLE, 0),
+			tds.rush_epa_allowed_rank::INTEGER,
+			COALESCE(TRY_CAST(REPLACE(tds.rush_success_rate_allowed::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(tds.rush_success_rate_allowed_rank AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(tds.dropback_epa_allowed AS DOUBLE), 0),
+			tds.dropback_epa_allowed_rank::INTEGER,
+			COALESCE(TRY_CAST(REPLACE(tds.dropback_success_rate_allowed::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(tds.dropback_success_rate_allowed_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.explosive_play_rate_allowed::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.explosive_play_rate_allowed_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.pressure_rate::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.pressure_rate_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.blitz_rate::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.blitz_rate_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.man_rate::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.man_rate_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.zone_rate::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.zone_rate_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.rush_stuff_rate::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.rush_stuff_rate_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.yards_before_contact_per_rb_rush::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.yards_before_contact_per_rb_rush_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.down_conversion_rate_allowed::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.down_conversion_rate_allowed_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sds.yards_per_play_allowed::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sds.yards_per_play_allowed_rank AS DOUBLE) AS INTEGER),
+			COALESCE(sumer.adot::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sumer.adot_rank AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(REPLACE(sumer."scramble_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."scramble_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(REPLACE(sumer."int_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."int_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(sds.ypt_allowed_wr::DOUBLE, 0),
+			sds.ypt_allowed_wr_rank::INTEGER,
+			COALESCE(sds.ypt_allowed_te::DOUBLE, 0),
+			sds.ypt_allowed_te_rank::INTEGER,
+			COALESCE(sds.ypt_allowed_rb::DOUBLE, 0),
+			sds.ypt_allowed_rb_rank::INTEGER
+		FROM
+			nfl_data.nfl_team_defensive_stats_db tds
+			JOIN nfl_data.nfl_sharp_defense_stats sds
+				ON tds.team_name = sds.team
+			JOIN nfl_data.nfl_sumer_defense_stats sumer
+				ON sumer.team = tds.team_name
+		ORDER BY tds.team_name
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team defense rankings: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []models.NFLTeamDefenseStatsV2
+	for rows.Next() {
+		var s models.NFLTeamDefenseStatsV2
+		err := rows.Scan(
+			&s.TeamName,
+			&s.SacksRate, &s.SacksRateRank,
+			&s.EPAperPlayAllowed, &s.EPAperPlayAllowedRank,
+			&s.SuccessRateAllowed, &s.SuccessRateAllowedRank,
+			&s.RushEPAAllowed, &s.RushEPAAllowedRank,
+			&s.RushSuccessRateAllowed, &s.RushSuccessRateAllowedRank,
+			&s.DropbackEPAAllowed, &s.DropbackEPAAllowedRank,
+			&s.DropbackSuccessRateAllowed, &s.DropbackSuccessRateAllowedRank,
+			&s.ExplosivePlayRateAllowed, &s.ExplosivePlayRateAllowedRank,
+			&s.PressureRate, &s.PressureRateRank,
+			&s.BlitzRate, &s.BlitzRateRank,
+			&s.ManRate, &s.ManRateRank,
+			&s.ZoneRate, &s.ZoneRateRank,
+			&s.RushStuffRate, &s.RushStuffRateRank,
+			&s.YardsBeforeContactPerRbRush, &s.YardsBeforeContactPerRbRushRank,
+			&s.DownConversionRateAllowed, &s.DownConversionRateAllowedRank,
+			&s.YardsPerPlayAllowed, &s.YardsPerPlayAllowedRank,
+			&s.Adot, &s.AdotRank,
+			&s.ScrambleRate, &s.ScrambleRateRank,
+			&s.IntRate, &s.IntRateRank,
+			&s.YardsAllowedWR, &s.YardsAllowedWRRank,
+			&s.YardsAllowedTE, &s.YardsAllowedTERank,
+			&s.YardsAllowedRB, &s.YardsAllowedRBRank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team defense rankings row: %w", err)
+		}
+		teams = append(teams, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over team defense rankings rows: %w", err)
+	}
+	return teams, nil
+}
+
+// GetNFLTeamOffenseRankings retrieves offensive stats for every team. String-typed
+// percentages and ranks from the source tables are cast to numbers; a rank the
+// source lacks stays NULL instead of reading as first place.
+func GetNFLTeamOffenseRankings(db *sql.DB) ([]models.NFLTeamOffenseStatsV2, error) {
+	query := `
+		SELECT
+			oa.team_name,
+			COALESCE(sumer."epa/play"::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sumer."epa/play_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(oa.dropback_epa::DOUBLE, 0),
+			oa.dropback_epa_rank::INTEGER,
+			COALESCE(oa.rush_epa::DOUBLE, 0),
+			oa.rush_epa_rank::INTEGER,
+			COALESCE(TRY_CAST(REPLACE(sumer."success_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."success_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(REPLACE(oa.rush_success_rate::VARCHAR, '%', '') AS DOUBLE), 0),
+			oa.rush_success_rate_rank::INTEGER,
+			COALESCE(TRY_CAST(REPLACE(oa.dropback_success_rate::VARCHAR, '%', '') AS DOUBLE), 0),
+			oa.dropback_success_rate_rank::INTEGER,
+			COALESCE(ps.passingYardsPerGame::DOUBLE, 0),
+			ps.passingYardsPerGame_rank::INTEGER,
+			COALESCE(ps.yardsPerCompletion::DOUBLE, 0),
+			ps.yardsPerCompletion_rank::INTEGER,
+			COALESCE(TRY_CAST(REPLACE(sumer."sack_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."sack_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(rs.rushingAttempts::DOUBLE, 0),
+			rs.rushingAttempts_rank::INTEGER,
+			COALESCE(rs.yardsPerRushAttempt::DOUBLE, 0),
+			rs.yardsPerRushAttempt_rank::INTEGER,
+			COALESCE(ps.passingAttempts::DOUBLE, 0),
+			ps.passingAttempts_rank::INTEGER,
+			COALESCE(sumer.adot::DOUBLE, 0),
+			TRY_CAST(TRY_CAST(sumer.adot_rank AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(REPLACE(sumer."scramble_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."scramble_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(TRY_CAST(REPLACE(sumer."int_%"::VARCHAR, '%', '') AS DOUBLE), 0),
+			TRY_CAST(TRY_CAST(sumer."int_%_rank" AS DOUBLE) AS INTEGER),
+			COALESCE(sos.time_to_throw::DOUBLE, 0),
+			sos.time_to_throw_rank::INTEGER,
+			COALESCE(sos.pressure_rate_allowed::DOUBLE, 0),
+			sos.pressure_rate_allowed_rank::INTEGER,
+			COALESCE(sos.explosive_play_rate::DOUBLE, 0),
+			sos.explosive_play_rate_rank::INTEGER,
+			COALESCE(sos.rush_stuff_rate::DOUBLE, 0),
+			sos.rush_stuff_rate_rank::INTEGER
+		FROM nfl_data.nfl_team_offense_advanced_stats oa
+		JOIN nfl_data.nfl_team_passing_stats_db ps ON oa.team_name = ps.team_name
+		JOIN nfl_data.nfl_team_rushing_stats_db rs ON oa.team_name = rs.team_name
+		JOIN nfl_data.nfl_sharp_offense_stats sos
+				ON oa.team_name = sos.team
+		JOIN nfl_data.nfl_sumer_offense_stats sumer
+				ON sumer.team = oa.team_name
+		ORDER BY oa.team_name
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team offense rankings: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []models.NFLTeamOffenseStatsV2
+	for rows.Next() {
+		var s models.NFLTeamOffenseStatsV2
+		err := rows.Scan(
+			&s.TeamName,
+			&s.EPAperPlay, &s.EPAperPlayRank,
+			&s.DropbackEPA, &s.DropbackEPARank,
+			&s.RushEPA, &s.RushEPARank,
+			&s.SuccessRate, &s.SuccessRateRank,
+			&s.RushSuccessRate, &s.RushSuccessRateRank,
+			&s.DropbackSuccessRate, &s.DropbackSuccessRateRank,
+			&s.PassingYardsPerGame, &s.PassingYardsPerGameRank,
+			&s.YardsPerCompletion, &s.YardsPerCompletionRank,
+			&s.SackRate, &s.SackRateRank,
+			&s.RushingAttempts, &s.RushingAttemptsRank,
+			&s.YardsPerRushAttempt, &s.YardsPerRushAttemptRank,
+			&s.PassingAttempts, &s.PassingAttemptsRank,
+			&s.Adot, &s.AdotRank,
+			&s.ScrambleRate, &s.ScrambleRateRank,
+			&s.IntRate, &s.IntRateRank,
+			&s.TimeToThrow, &s.TimeToThrowRank,
+			&s.PressureRateAllowed, &s.PressureRateAllowedRank,
+			&s.ExplosivePlayRate, &s.ExplosivePlayRateRank,
+			&s.RushStuffRate, &s.RushStuffRateRank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team offense rankings row: %w", err)
+		}
+		teams = append(teams, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over team offense rankings rows: %w", err)
+	}
+	return teams, nil
+}
+
+// GetNFLDefenseVsPosition computes what every defense allows per game to QB/RB/WR/TE for a
+// season, from nfl_player_gamelog joined with roster positions. A player's defense is the
+// opponent, in that game's event, of the team the snap counts have them playing for, so traded
+// players are credited against the defenses they actually faced. Fantasy points use PPR scoring.
+func GetNFLDefenseVsPosition(db *sql.DB, season int) ([]models.NFLDefenseVsPosition, error) {
+	query := `
+		WITH qb AS (
+			SELECT
+				player_id,
+				game_id,
+				COALESCE(passingYards::DOUBLE, 0) AS passing_yards,
+				COALESCE(passingTouchdowns::DOUBLE, 0) AS passing_tds,
+				COALESCE(interceptions::DOUBLE, 0) AS interceptions
+			FROM nfl_data.nfl_qb_gamelog
+			WHERE season = ?
+			QUALIFY ROW_NUMBER() OVER (PARTITION BY player_id, game_id) = 1
+		),
+		player_games AS (
+			SELECT
+				gl.game_id,
+				gl.game_week,
+				CASE WHEN ev.home_team = gt.team_name THEN ev.away_team ELSE ev.home_team END AS defense,
+				r.position,
+				COALESCE(gl.rushingYards::DOUBLE, 0) AS rushing_yards,
+				COALESCE(gl.receivingYards::DOUBLE, 0) AS receiving_yards,
+				COALESCE(gl.receptions::DOUBLE, 0) AS receptions,
+				COALESCE(gl.receivingTargets::DOUBLE, 0) AS targets,
+				COALESCE(gl.rushingTouchdowns::DOUBLE, 0) + COALESCE(gl.receivingTouchdowns::DOUBLE, 0) AS touchdowns,
+				0.1 * COALESCE(gl.rushingYards::DOUBLE, 0)
+					+ 0.1 * COALESCE(gl.receivingYards::DOUBLE, 0)
+					+ COALESCE(gl.receptions::DOUBLE, 0)
+					+ 6 * (COALESCE(gl.rushingTouchdowns::DOUBLE, 0) + COALESCE(gl.receivingTouchdowns::DOUBLE, 0))
+					- 2 * COALESCE(gl.fumblesLost::DOUBLE, 0)
+					+ 0.04 * COALESCE(qb.passing_yards, 0)
+					+ 4 * COALESCE(qb.passing_tds, 0)
+					- 2 * COALESCE(qb.interceptions, 0) AS fantasy_points
+			FROM nfl_data.nfl_player_gamelog gl
+			JOIN nfl_data.nfl_roster_db r ON gl.player_id = r.player_id
+			JOIN nfl_data.nfl_player_snap_counts ps
+				ON ps.player_id = gl.player_id
+				AND ps.season = gl.season
+				AND ps.game_week = gl.game_week
+			JOIN ` + nflTeamNamesSQL + ` ON gt.team_id = ps.team
+			JOIN nfl_data.nfl_game_events_db ev
+				ON ev.event_id = gl.game_id
+				AND gt.team_name IN (ev.home_team, ev.away_team)
+			LEFT JOIN qb ON qb.player_id = gl.player_id AND qb.game_id = gl.game_id
+			WHERE gl.season = ?
+				AND r.position IN ('QB', 'RB', 'WR', 'TE')
+			QUALIFY ROW_NUMBER() OVER (PARTITION BY gl.player_id, gl.game_id) = 1
+		),
+		defense_games AS (
+			SELECT
+				defense,
+				game_id,
+				DENSE_RANK() OVER (PARTITION BY defense ORDER BY game_week DESC) AS recency
+			FROM (SELECT DISTINCT game_id, game_week, defense FROM player_games)
+		),
+		games AS (
+			SELECT
+				defense,
+				COUNT(*) AS games,
+				COUNT(*) FILTER (WHERE recency <= 4) AS l4_games
+			FROM defense_games
+			GROUP BY defense
+		),
+		totals AS (
+			SELECT
+				dg.defense,
+				pg.position,
+				SUM(pg.rushing_yards) AS rushing_yards,
+				SUM(pg.receiving_yards) AS receiving_yards,
+				SUM(pg.receptions) AS receptions,
+				SUM(pg.targets) AS targets,
+				SUM(pg.touchdowns) AS touchdowns,
+				SUM(pg.fantasy_points) AS fantasy_points,
+				COALESCE(SUM(pg.rushing_yards) FILTER (WHERE dg.recency <= 4), 0) AS l4_rushing_yards,
+				COALESCE(SUM(pg.receiving_yards) FILTER (WHERE dg.recency <= 4), 0) AS l4_receiving_yards,
+				COALESCE(SUM(pg.receptions) FILTER (WHERE dg.recency <= 4), 0) AS l4_receptions,
+				COALESCE(SUM(pg.targets) FILTER (WHERE dg.recency <= 4), 0) AS l4_targets,
+				COALESCE(SUM(pg.touchdowns) FILTER (WHERE dg.recency <= 4), 0) AS l4_touchdowns,
+				COALESCE(SUM(pg.fantasy_points) FILTER (WHERE dg.recency <= 4), 0) AS l4_fantasy_points
+			FROM player_games pg
+			JOIN defense_games dg ON dg.game_id = pg.game_id AND dg.defense = pg.defense
+			GROUP BY dg.defense, pg.position
+		),
+		per_game AS (
+			SELECT
+				g.defense,
+				p.position,
+				g.games,
+				g.l4_games,
+				COALESCE(t.rushing_yards, 0) / g.games AS rushing_yards,
+				COALESCE(t.receiving_yards, 0) / g.games AS receiving_yards,
+				COALESCE(t.receptions, 0) / g.games AS receptions,
+				COALESCE(t.targets, 0) / g.games AS targets,
+				COALESCE(t.touchdowns, 0) / g.games AS touchdowns,
+				COALESCE(t.fantasy_points, 0) / g.games AS fantasy_points,
+				COALESCE(t.l4_rushing_yards / NULLIF(g.l4_games, 0), 0) AS l4_rushing_yards,
+				COALESCE(t.l4_receiving_yards / NULLIF(g.l4_games, 0), 0) AS l4_receiving_yards,
+				COALESCE(t.l4_receptions / NULLIF(g.l4_games, 0), 0) AS l4_receptions,
+				COALESCE(t.l4_targets / NULLIF(g.l4_games, 0), 0) AS l4_targets,
+				COALESCE(t.l4_touchdowns / NULLIF(g.l4_games, 0), 0) AS l4_touchdowns,
+				COALESCE(t.l4_fantasy_points / NULLIF(g.l4_games, 0), 0) AS l4_fantasy_points
+			FROM games g
+			-- every defense gets every position, so one that allowed nothing still ranks
+			CROSS JOIN (VALUES ('QB'), ('RB'), ('WR'), ('TE')) AS p(position)
+			LEFT JOIN totals t ON t.defense = g.defense AND t.position = p.position
+		)
+		SELECT
+			defense,
+			position,
+			games,
+			rushing_yards,
+			RANK() OVER (PARTITION BY position ORDER BY rushing_yards DESC),
+			receiving_yards,
+			RANK() OVER (PARTITION BY position ORDER BY receiving_yards DESC),
+			receptions,
+			RANK() OVER (PARTITION BY position ORDER BY receptions DESC),
+			targets,
+			RANK() OVER (PARTITION BY position ORDER BY targets DESC),
+			touchdowns,
+			RANK() OVER (PARTITION BY position ORDER BY touchdowns DESC),
+			fantasy_points,
+			RANK() OVER (PARTITION BY position ORDER BY fantasy_points DESC),
+			l4_games,
+			l4_rushing_yards,
+			l4_receiving_yards,
+			l4_receptions,
+			l4_targets,
+			l4_touchdowns,
+			l4_fantasy_points
+		FROM per_game
+		ORDER BY position, defense
+	`
+
+	rows, err := db.Query(query, season, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query defense vs position: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.NFLDefenseVsPosition
+	for rows.Next() {
+		var s models.NFLDefenseVsPosition
+		err := rows.Scan(
+			&s.TeamName,
+			&s.Position,
+			&s.Games,
+			&s.RushingYards, &s.RushingYardsRank,
+			&s.ReceivingYards, &s.ReceivingYardsRank,
+			&s.Receptions, &s.ReceptionsRank,
+			&s.Targets, &s.TargetsRank,
+			&s.Touchdowns, &s.TouchdownsRank,
+			&s.FantasyPoints, &s.FantasyPointsRank,
+			&s.Last4.Games,
+			&s.Last4.RushingYards,
+			&s.Last4.ReceivingYards,
+			&s.Last4.Receptions,
+			&s.Last4.Targets,
+			&s.Last4.Touchdowns,
+			&s.Last4.FantasyPoints,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan defense vs position row: %w", err)
+		}
+		s.Last4.FantasyPointsDelta = s.Last4.FantasyPoints - s.FantasyPoints
+		stats = append(stats, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over defense vs position rows: %w", err)
+	}
+	return stats, nil
+}
+
+// GetNFLDefensePassingPBPStats retrieves every pass play thrown against a defense in a season
+func GetNFLDefensePassingPBPStats(db *sql.DB, teamName string, season int) ([]models.NFLPassingPBPStats, error) {
+	query := `
+		SELECT 
+			week,
+			opponent,
+			complete_pass, 
+			interception, 
+			air_yards, 
+			pass_location, 
+			pass_length 
+		FROM nfl_data.nfl_pbp_qb_data 
+		WHERE opponent = ?
+		AND season = ?
+		AND pass_location IS NOT NULL
+		AND pass_length IS NOT NULL
+		AND air_yards IS NOT NULL
+	`
+
+	var passingPBPStats []models.NFLPassingPBPStats
+	rows, err := db.Query(query, teamName, season)
+	if err != nil {
+		return []models.NFLPassingPBPStats{}, fmt.Errorf("failed to query defense passing PBP stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var passingPBPStat models.NFLPassingPBPStats
+		err := rows.Scan(
+			&passingPBPStat.Week,
+			&passingPBPStat.Opponent,
+			&passingPBPStat.CompletePass,
+			&passingPBPStat.Interception,
+			&passingPBPStat.AirYards,
+			&passingPBPStat.PassLocation,
+			&passingPBPStat.PassLength,
+		)
+		if err != nil {
+			return []models.NFLPassingPBPStats{}, fmt.Errorf("failed to scan defense passing PBP stats row: %w", err)
+		}
+		passingPBPStats = append(passingPBPStats, passingPBPStat)
+	}
+	if err = rows.Err(); err != nil {
+		return []models.NFLPassingPBPStats{}, fmt.Errorf("error iterating over defense passing PBP stats rows: %w", err)
+	}
+	return passingPBPStats, nil
+}
+
+// GetNFLLatestSeason returns the most recent season in the player gamelog
+func GetNFLLatestSeason(db *sql.DB) (int, error) {
+	query := `SELECT MAX(season)::INTEGER FROM nfl_data.nfl_player_gamelog`
+
+	var season int
+	if err := db.QueryRow(query).Scan(&season); err != nil {
+		return 0, fmt.Errorf("failed to query latest season: %w", err)
+	}
+	return season, nil
+}
+
+// GetNFLReceiverUsage retrieves weekly receiving usage with team totals for a season, optionally
+// limited to one player ID and/or team ID (empty strings match everything). Air yards come from the PBP
+// data, whose receiver names are abbreviated ("T.Kelce"), so gamelog names are abbreviated to match
+// and paired with the team (posteam) the player played for that week. The team name, team totals
+// and team filter all use that per-week team, so a traded player's weeks count for the team they
+// played them for. Rate fields are left for the caller to compute.
+func GetNFLReceiverUsage(db *sql.DB, season int, playerID string, teamID string) ([]models.NFLReceiverUsage, error) {
+	query := `
+		WITH player_games AS (
+			SELECT
+				gl.player_id,
+				gl.player_name,
+				r.team_id,
+				gl.season,
+				gl.game_week,
+				COALESCE(gl.receivingTargets::INTEGER, 0) AS targets,
+				COALESCE(gl.receptions::INTEGER, 0) AS receptions,
+				COALESCE(gl.receivingYards::INTEGER, 0) AS receiving_yards,
+				` + nflPBPNameSQL("gl.player_name") + ` AS pbp_name
+			FROM nfl_data.nfl_player_gamelog gl
+			JOIN nfl_data.nfl_roster_db r ON gl.player_id = r.player_id
+			WHERE gl.season = ?
+			QUALIFY ROW_NUMBER() OVER (PARTITION BY gl.player_id, gl.game_id) = 1
+		),
+		air AS (
+			SELECT week, posteam, receiver, SUM(COALESCE(air_yards, 0))::DOUBLE AS air_yards
+			FROM nfl_data.nfl_pbp_qb_data
+			WHERE season = ?
+				AND receiver IS NOT NULL
+			GROUP BY week, posteam, receiver
+		),
+		player_weeks AS (
+			SELECT
+				pg.player_id,
+				pg.player_name,
+				COALESCE(ps.team, pg.team_id) AS game_team,
+				pg.game_week,
+				pg.targets,
+				pg.receptions,
+				pg.receiving_yards,
+				COALESCE(ps.offense_snaps::INTEGER, 0) AS offense_snaps,
+				COALESCE(a.air_yards, 0) AS air_yards
+			FROM player_games pg
+			LEFT JOIN nfl_data.nfl_player_snap_counts ps
+				ON ps.player_id = pg.player_id
+				AND ps.season = pg.season
+				AND ps.game_week = pg.game_week
+			LEFT JOIN air a
+				ON a.week = pg.game_week
+				AND a.posteam = COALESCE(ps.team, pg.team_id)
+				AND a.receiver = pg.pbp_name
+		)
+		SELECT
+			pw.player_name,
+			COALESCE(gt.team_name, pw.game_team),
+			pw.game_week,
+			pw.targets,
+			pw.receptions,
+			pw.receiving_yards,
+			pw.offense_snaps,
+			pw.air_yards,
+			SUM(pw.targets) OVER (PARTITION BY pw.game_team, pw.game_week)::INTEGER AS team_targets,
+			SUM(pw.air_yards) OVER (PARTITION BY pw.game_team, pw.game_week) AS team_air_yards
+		FROM player_weeks pw
+		LEFT JOIN ` + nflTeamNamesSQL + ` ON gt.team_id = pw.game_team
+		QUALIFY (? = '' OR pw.player_id::VARCHAR = ?)
+			AND (? = '' OR pw.game_team = ?)
+		ORDER BY pw.player_name, pw.game_week
+	`
+
+	rows, err := db.Query(query, season, season, playerID, playerID, teamID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query receiver usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []models.NFLReceiverUsage
+	for rows.Next() {
+		var u models.NFLReceiverUsage
+		err := rows.Scan(
+			&u.PlayerName,
+			&u.TeamName,
+			&u.Week,
+			&u.Targets,
+			&u.Receptions,
+			&u.ReceivingYards,
+			&u.OffenseSnaps,
+			&u.AirYards,
+			&u.TeamTargets,
+			&u.TeamAirYards,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan receiver usage row: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over receiver usage rows: %w", err)
+	}
+	return usage, nil
+}
+
+// GetNFLSnapCounts retrieves weekly offensive snap counts for a season with week-over-week changes
+// and 3-game rolling averages, optionally limited to a player ID, team ID and/or week (empty strings and
+// 0 match everything). Each week is credited to the team the player played it for, falling back to
+// the roster team. Changes and averages are computed before the filters are applied.
+func GetNFLSnapCounts(db *sql.DB, season int, playerID string, teamID string, week int) ([]models.NFLSnapCountWeek, error) {
+	query := `
+		WITH snaps AS (
+			SELECT
+				ps.player_id,
+				r.player_name,
+				COALESCE(gt.team_name, r.team_name) AS team_name,
+				COALESCE(ps.team, r.team_id) AS team_id,
+				r.position,
+				ps.game_week,
+				COALESCE(ps.offense_snaps::INTEGER, 0) AS snaps,
+				COALESCE(ps.offense_snap_pct::DOUBLE, 0) AS snap_pct
+			FROM nfl_data.nfl_player_snap_counts ps
+			JOIN nfl_data.nfl_roster_db r ON ps.player_id = r.player_id
+			LEFT JOIN ` + nflTeamNamesSQL + ` ON gt.team_id = ps.team
+			WHERE ps.season = ?
+			QUALIFY ROW_NUMBER() OVER (PARTITION BY ps.player_id, ps.game_week) = 1
+		)
+		SELECT
+			player_name,
+			team_name,
+			position,
+			game_week,
+			snaps,
+			snap_pct,
+			COALESCE(LAG(game_week) OVER (PARTITION BY player_id ORDER BY game_week), 0),
+			COALESCE(snaps - LAG(snaps) OVER (PARTITION BY player_id ORDER BY game_week), 0),
+			COALESCE(snap_pct - LAG(snap_pct) OVER (PARTITION BY player_id ORDER BY game_week), 0),
+			AVG(snaps) OVER (PARTITION BY player_id ORDER BY game_week ROWS BETWEEN 2 PRECEDING AND CURRENT ROW),
+			AVG(snap_pct) OVER (PARTITION BY player_id ORDER BY game_week ROWS BETWEEN 2 PRECEDING AND CURRENT ROW)
+		FROM snaps
+		QUALIFY (? = '' OR player_id::VARCHAR = ?)
+			AND (? = '' OR team_id = ?)
+			AND (? = 0 OR game_week = ?)
+		ORDER BY player_name, game_week
+	`
+
+	rows, err := db.Query(query, season, playerID, playerID, teamID, teamID, week, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snap counts: %w", err)
+	}
+	defer rows.Close()
+
+	var snaps []models.NFLSnapCountWeek
+	for rows.Next() {
+		var s models.NFLSnapCountWeek
+		err := rows.Scan(
+			&s.PlayerName,
+			&s.TeamName,
+			&s.Position,
+			&s.Week,
+			&s.OffenseSnaps,
+			&s.OffenseSnapPct,
+			&s.PriorWeek,
+			&s.SnapsChange,
+			&s.SnapPctChange,
+			&s.RollingSnaps,
+			&s.RollingSnapPct,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan snap counts row: %w", err)
+		}
+		snaps = append(snaps, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over snap counts rows: %w", err)
+	}
+	return snaps, nil
+}
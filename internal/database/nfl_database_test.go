@@ -0,0 +1,280 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/marcboeker/go-duckdb/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openFixture opens an in-memory database holding the given statements
+func openFixture(t *testing.T, statements ...string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("duckdb", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	for _, stmt := range statements {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+	return db
+}
+
+func TestGetNFLDefenseVsPositionUsesPerGameTeam(t *testing.T) {
+	// Adams is on the Jets' roster now but played week 2 for the Raiders against the Chiefs
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, player_name VARCHAR, "position" VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Travis Kelce', 'TE', 'Kansas City Chiefs', 'KC'), ('3', 'Davante Adams', 'WR', 'New York Jets', 'NYJ')`,
+		`CREATE TABLE nfl_data.nfl_player_gamelog (player_id VARCHAR, game_id VARCHAR, season INT, game_week INT, rushingYards INT, receivingYards INT, receptions INT, receivingTargets INT, rushingTouchdowns INT, receivingTouchdowns INT, fumblesLost INT)`,
+		`INSERT INTO nfl_data.nfl_player_gamelog VALUES
+			('1', 'g1', 2024, 1, 0, 60, 6, 8, 0, 1, 0),
+			('1', 'g2', 2024, 2, 0, 40, 4, 5, 0, 0, 0),
+			('3', 'g2', 2024, 2, 0, 90, 7, 10, 0, 1, 0)`,
+		`CREATE TABLE nfl_data.nfl_qb_gamelog (player_id VARCHAR, game_id VARCHAR, season INT, passingYards INT, passingTouchdowns INT, interceptions INT)`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES ('1', 2024, 1, 'KC'), ('1', 2024, 2, 'KC'), ('3', 2024, 2, 'LV')`,
+		`CREATE TABLE nfl_data.nfl_game_events_db (event_id VARCHAR, home_team VARCHAR, away_team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_game_events_db VALUES ('g1', 'Kansas City Chiefs', 'Baltimore Ravens'), ('g2', 'Las Vegas Raiders', 'Kansas City Chiefs')`,
+	)
+
+	stats, err := GetNFLDefenseVsPosition(db, 2024)
+	require.NoError(t, err)
+
+	allowed := map[string]float64{}
+	for _, s := range stats {
+		assert.Equal(t, 1, s.Games, s.TeamName)
+		if s.ReceivingYards > 0 {
+			allowed[s.TeamName+" "+s.Position] = s.ReceivingYards
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"Baltimore Ravens TE":   60,
+		"Las Vegas Raiders TE":  40,
+		"Kansas City Chiefs WR": 90,
+	}, allowed)
+}
+
+func TestGetNFLDefenseVsPositionRanksZeroAllowed(t *testing.T) {
+	// the Ravens faced a tight end but no receiver
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, player_name VARCHAR, "position" VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Travis Kelce', 'TE', 'Kansas City Chiefs', 'KC'), ('2', 'Zay Flowers', 'WR', 'Baltimore Ravens', 'BAL')`,
+		`CREATE TABLE nfl_data.nfl_player_gamelog (player_id VARCHAR, game_id VARCHAR, season INT, game_week INT, rushingYards INT, receivingYards INT, receptions INT, receivingTargets INT, rushingTouchdowns INT, receivingTouchdowns INT, fumblesLost INT)`,
+		`INSERT INTO nfl_data.nfl_player_gamelog VALUES ('1', 'g1', 2024, 1, 0, 60, 6, 8, 0, 1, 0), ('2', 'g1', 2024, 1, 0, 70, 5, 9, 0, 0, 0)`,
+		`CREATE TABLE nfl_data.nfl_qb_gamelog (player_id VARCHAR, game_id VARCHAR, season INT, passingYards INT, passingTouchdowns INT, interceptions INT)`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES ('1', 2024, 1, 'KC'), ('2', 2024, 1, 'BAL')`,
+		`CREATE TABLE nfl_data.nfl_game_events_db (event_id VARCHAR, home_team VARCHAR, away_team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_game_events_db VALUES ('g1', 'Kansas City Chiefs', 'Baltimore Ravens')`,
+	)
+
+	stats, err := GetNFLDefenseVsPosition(db, 2024)
+	require.NoError(t, err)
+	require.Len(t, stats, 8)
+
+	type allowed struct {
+		games int
+		yards float64
+		rank  int
+	}
+	got := map[string]allowed{}
+	for _, s := range stats {
+		got[s.TeamName+" "+s.Position] = allowed{s.Games, s.ReceivingYards, s.ReceivingYardsRank}
+	}
+	assert.Equal(t, allowed{1, 60, 1}, got["Baltimore Ravens TE"])
+	assert.Equal(t, allowed{1, 0, 2}, got["Kansas City Chiefs TE"])
+	assert.Equal(t, allowed{1, 70, 1}, got["Kansas City Chiefs WR"])
+	assert.Equal(t, allowed{1, 0, 2}, got["Baltimore Ravens WR"])
+}
+
+func TestGetNFLReceiverUsageMatchesAirYardsByTeam(t *testing.T) {
+	// both receivers abbreviate to "J.Smith" in the play-by-play
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Kansas City Chiefs', 'KC'), ('2', 'Buffalo Bills', 'BUF')`,
+		`CREATE TABLE nfl_data.nfl_player_gamelog (player_id VARCHAR, player_name VARCHAR, game_id VARCHAR, season INT, game_week INT, receivingTargets INT, receptions INT, receivingYards INT)`,
+		`INSERT INTO nfl_data.nfl_player_gamelog VALUES ('1', 'John Smith', 'g1', 2024, 1, 6, 4, 50), ('2', 'Jake Smith', 'g2', 2024, 1, 3, 2, 20)`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, offense_snaps INT, team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES ('1', 2024, 1, 50, 'KC'), ('2', 2024, 1, 30, 'BUF')`,
+		`CREATE TABLE nfl_data.nfl_pbp_qb_data (season INT, week INT, posteam VARCHAR, receiver VARCHAR, air_yards DOUBLE)`,
+		`INSERT INTO nfl_data.nfl_pbp_qb_data VALUES (2024, 1, 'KC', 'J.Smith', 40), (2024, 1, 'KC', 'J.Smith', 15), (2024, 1, 'BUF', 'J.Smith', 12)`,
+	)
+
+	usage, err := GetNFLReceiverUsage(db, 2024, "", "")
+	require.NoError(t, err)
+	require.Len(t, usage, 2)
+
+	airYards := map[string]float64{}
+	for _, u := range usage {
+		airYards[u.PlayerName] = u.AirYards
+		assert.Equal(t, u.AirYards, u.TeamAirYards, u.PlayerName)
+	}
+	assert.Equal(t, map[string]float64{"John Smith": 55, "Jake Smith": 12}, airYards)
+}
+
+func TestGetNFLReceiverUsageUsesPerGameTeam(t *testing.T) {
+	// Adams played week 1 for the Raiders and week 2 for the Jets, who roster Adams now
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'New York Jets', 'NYJ'), ('2', 'Las Vegas Raiders', 'LV'), ('3', 'New York Jets', 'NYJ')`,
+		`CREATE TABLE nfl_data.nfl_player_gamelog (player_id VARCHAR, player_name VARCHAR, game_id VARCHAR, season INT, game_week INT, receivingTargets INT, receptions INT, receivingYards INT)`,
+		`INSERT INTO nfl_data.nfl_player_gamelog VALUES
+			('1', 'Davante Adams', 'g1', 2024, 1, 10, 6, 80),
+			('2', 'Jakobi Meyers', 'g1', 2024, 1, 5, 3, 30),
+			('1', 'Davante Adams', 'g2', 2024, 2, 8, 5, 60),
+			('3', 'Garrett Wilson', 'g2', 2024, 2, 12, 7, 90)`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, offense_snaps INT, team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES ('1', 2024, 1, 60, 'LV'), ('2', 2024, 1, 55, 'LV'), ('1', 2024, 2, 50, 'NYJ'), ('3', 2024, 2, 65, 'NYJ')`,
+		`CREATE TABLE nfl_data.nfl_pbp_qb_data (season INT, week INT, posteam VARCHAR, receiver VARCHAR, air_yards DOUBLE)`,
+	)
+
+	type week struct {
+		player      string
+		team        string
+		week        int
+		teamTargets int
+	}
+	teamWeeks := func(teamID string) []week {
+		usage, err := GetNFLReceiverUsage(db, 2024, "", teamID)
+		require.NoError(t, err)
+		weeks := []week{}
+		for _, u := range usage {
+			weeks = append(weeks, week{u.PlayerName, u.TeamName, u.Week, u.TeamTargets})
+		}
+		return weeks
+	}
+
+	assert.Equal(t, []week{
+		{"Davante Adams", "Las Vegas Raiders", 1, 15},
+		{"Jakobi Meyers", "Las Vegas Raiders", 1, 15},
+	}, teamWeeks("LV"))
+	assert.Equal(t, []week{
+		{"Davante Adams", "New York Jets", 2, 20},
+		{"Garrett Wilson", "New York Jets", 2, 20},
+	}, teamWeeks("NYJ"))
+}
+
+func TestGetNFLSnapCountsTrend(t *testing.T) {
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, player_name VARCHAR, "position" VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Isiah Pacheco', 'RB', 'Kansas City Chiefs', 'KC')`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, offense_snaps INT, offense_snap_pct DOUBLE, team VARCHAR)`,
+		// week 3 is a missed game, so week 4 compares against week 2
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES ('1', 2024, 1, 40, 0.6, 'KC'), ('1', 2024, 2, 50, 0.7, 'KC'), ('1', 2024, 4, 30, 0.4, 'KC'), ('1', 2024, 5, 45, 0.7, 'KC')`,
+	)
+
+	weeks, err := GetNFLSnapCounts(db, 2024, "", "KC", 4)
+	require.NoError(t, err)
+	require.Len(t, weeks, 1)
+	w := weeks[0]
+	assert.Equal(t, 2, w.PriorWeek)
+	assert.Equal(t, -20, w.SnapsChange)
+	assert.InDelta(t, -0.3, w.SnapPctChange, 1e-9)
+	assert.InDelta(t, 40, w.RollingSnaps, 1e-9)
+	assert.InDelta(t, 1.7/3, w.RollingSnapPct, 1e-9)
+
+	weeks, err = GetNFLSnapCounts(db, 2024, "1", "", 0)
+	require.NoError(t, err)
+	require.Len(t, weeks, 4)
+	assert.Equal(t, 0, weeks[0].PriorWeek)
+	assert.Equal(t, 0, weeks[0].SnapsChange)
+}
+
+func TestGetNFLSnapCountsUsesPerGameTeam(t *testing.T) {
+	// Adams played week 1 for the Raiders and week 2 for the Jets, who roster Adams now
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, player_name VARCHAR, "position" VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Davante Adams', 'WR', 'New York Jets', 'NYJ')`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, offense_snaps INT, offense_snap_pct DOUBLE, team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES ('1', 2024, 1, 60, 0.9, 'LV'), ('1', 2024, 2, 40, 0.6, 'NYJ')`,
+	)
+
+	weeks, err := GetNFLSnapCounts(db, 2024, "1", "", 0)
+	require.NoError(t, err)
+	require.Len(t, weeks, 2)
+	assert.Equal(t, "Las Vegas Raiders", weeks[0].TeamName)
+	assert.Equal(t, "New York Jets", weeks[1].TeamName)
+	// the trend still runs across the trade
+	assert.Equal(t, -20, weeks[1].SnapsChange)
+
+	weeks, err = GetNFLSnapCounts(db, 2024, "", "LV", 0)
+	require.NoError(t, err)
+	require.Len(t, weeks, 1)
+	assert.Equal(t, 1, weeks[0].Week)
+
+	weeks, err = GetNFLSnapCounts(db, 2024, "", "NYJ", 1)
+	require.NoError(t, err)
+	assert.Empty(t, weeks)
+}
+
+func TestGetRushingGameStatsFilters(t *testing.T) {
+	// Kelce's week 1 game is loaded twice and week 1 of 2023 belongs to the previous season
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_player_gamelog (game_id VARCHAR, player_id VARCHAR, player_name VARCHAR, season INT, game_date DATE, game_week INT, rushingAttempts INT, rushingYards INT, rushingTouchdowns INT, longRushing INT, receptions INT, receivingTargets INT, receivingYards INT, yardsPerReception DOUBLE, receivingTouchdowns INT, longReception INT, fumbles INT, fumblesLost INT)`,
+		`INSERT INTO nfl_data.nfl_player_gamelog VALUES
+			('g0', '1', 'Travis Kelce', 2023, '2023-09-07', 1, 0, 0, 0, 0, 4, 6, 30, 7.5, 0, 12, 0, 0),
+			('g1', '1', 'Travis Kelce', 2024, '2024-09-05', 1, 1, 5, 0, 5, 3, 5, 26, 8.7, 0, 15, 0, 0),
+			('g1', '1', 'Travis Kelce', 2024, '2024-09-05', 1, 1, 5, 0, 5, 3, 5, 26, 8.7, 0, 15, 0, 0),
+			('g2', '1', 'Travis Kelce', 2024, '2024-09-15', 2, 0, 0, 0, 0, 5, 7, 34, 6.8, 0, 11, 1, 0),
+			('g3', '1', 'Travis Kelce', 2024, '2024-09-22', 3, 0, 0, 0, 0, 3, 4, 30, 10, 1, 18, 0, 0)`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, offense_snaps INT, offense_snap_pct DOUBLE, team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_player_snap_counts VALUES
+			('1', 2023, 1, 50, 0.8, 'KC'),
+			('1', 2024, 1, 60, 0.9, 'KC'),
+			('1', 2024, 2, 55, 0.8, 'KC'),
+			('1', 2024, 3, 40, 0.7, 'KC')`,
+		`CREATE TABLE nfl_data.nfl_game_events_db (event_id VARCHAR, home_team VARCHAR, away_team VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_game_events_db VALUES
+			('g0', 'Kansas City Chiefs', 'Detroit Lions'),
+			('g1', 'Kansas City Chiefs', 'Baltimore Ravens'),
+			('g2', 'Cincinnati Bengals', 'Kansas City Chiefs'),
+			('g3', 'Atlanta Falcons', 'Kansas City Chiefs')`,
+	)
+
+	gameIDs := func(filter NFLGamelogFilter) []string {
+		gamelog, err := GetRushingGameStats(db, "1", filter)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, g := range gamelog.Games {
+			ids = append(ids, g.GameID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"g0", "g1", "g2", "g3"}, gameIDs(NFLGamelogFilter{}))
+	assert.Equal(t, []string{"g1", "g2", "g3"}, gameIDs(NFLGamelogFilter{Season: 2024}))
+	assert.Equal(t, []string{"g0", "g1"}, gameIDs(NFLGamelogFilter{WeekTo: 1}))
+	assert.Equal(t, []string{"g2", "g3"}, gameIDs(NFLGamelogFilter{Season: 2024, WeekFrom: 2}))
+	assert.Equal(t, []string{"g1"}, gameIDs(NFLGamelogFilter{Opponent: "Baltimore Ravens"}))
+	assert.Equal(t, []string{"g2", "g3"}, gameIDs(NFLGamelogFilter{HomeAway: "away"}))
+	assert.Equal(t, []string{"g0"}, gameIDs(NFLGamelogFilter{HomeAway: "home", Opponent: "Detroit Lions"}))
+
+	gamelog, err := GetRushingGameStats(db, "1", NFLGamelogFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, "Cincinnati Bengals", gamelog.Games[2].Opponent)
+	assert.Equal(t, "away", gamelog.Games[2].HomeAway)
+
+	require.Len(t, gamelog.Totals, 2)
+	assert.Equal(t, 2023, gamelog.Totals[0].Season)
+	assert.Equal(t, 1, gamelog.Totals[0].Games)
+	assert.Equal(t, 30, gamelog.Totals[0].ReceivingYards)
+
+	season := gamelog.Totals[1]
+	assert.Equal(t, 2024, season.Season)
+	assert.Equal(t, 3, season.Games)
+	assert.Equal(t, 11, season.Receptions)
+	assert.Equal(t, 90, season.ReceivingYards)
+	assert.Equal(t, 18, season.LongReception)
+	assert.Equal(t, 155, season.OffenseSnaps)
+	assert.InDelta(t, 90.0/11, season.YardsPerReception, 1e-9)
+	assert.InDelta(t, 0.8, season.OffenseSnapPct, 1e-9)
+}
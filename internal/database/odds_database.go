@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"sports_api/internal/models"
+)
+
+// propOddsTables maps each sport to its prop odds snapshot table
+var propOddsTables = map[string]string{
+	"nba": "nba_data.nba_prop_odds",
+	"nfl": "nfl_data.nfl_prop_odds",
+}
+
+// PropOddsSports lists the sports with prop odds, in a stable order
+func PropOddsSports() []string {
+	return []string{"nba", "nfl"}
+}
+
+// GetLatestPropOdds retrieves the most recent snapshot of every player, market and sportsbook
+// line for a sport
+func GetLatestPropOdds(db *sql.DB, sport string) ([]models.Odds, error) {
+	table, ok := propOddsTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unknown odds sport: %s", sport)
+	}
+
+	query := `
+		SELECT player, sport_book, market, line, over_odds, under_odds
+		FROM ` + table + `
+		WHERE sport_book IN ('FanDuel', 'DraftKings', 'BetMGM')
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY player, market, sport_book ORDER BY "timestamp" DESC) = 1
+	`
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s odds: %w", sport, err)
+	}
+	defer rows.Close()
+
+	var odds []models.Odds
+	for rows.Next() {
+		var odd models.Odds
+		err := rows.Scan(&odd.Name, &odd.Sportbook, &odd.Market, &odd.Line, &odd.Over, &odd.Under)
+		if err != nil {
+			return nil, fmt.Errorf("error in odds: %w", err)
+		}
+		odds = append(odds, odd)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over %s odds rows: %w", sport, err)
+	}
+	return odds, nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sports_api/internal/models"
+)
+
+// GetSearchCandidates retrieves every NBA and NFL player that /search can match. Teams come
+// from the team registry instead.
+func GetSearchCandidates(db *sql.DB) ([]models.SearchResult, error) {
+	query := `
+		SELECT DISTINCT 'nba' AS sport, 'player' AS type, PLAYER_ID::VARCHAR AS id, PLAYER AS name,
+			COALESCE(TEAM, '') AS team, COALESCE("POSITION", '') AS position
+		FROM nba_data.team_roster
+		UNION ALL
+		SELECT DISTINCT 'nfl', 'player', player_id::VARCHAR, player_name,
+			COALESCE(team_name, ''), COALESCE(position, '')
+		FROM nfl_data.nfl_roster_db
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query search candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.SearchResult
+	for rows.Next() {
+		var candidate models.SearchResult
+		err := rows.Scan(&candidate.Sport, &candidate.Type, &candidate.ID, &candidate.Name, &candidate.Team, &candidate.Position)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search candidate row: %w", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over search candidate rows: %w", err)
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"sports_api/internal/models"
+)
+
+// MarketComponents lists the gamelog columns a prop market's stat adds up, e.g. points,
+// reboundsTotal and assists for player_points_rebounds_assists
+func MarketComponents(sport, market string) ([]string, bool) {
+	stat, ok := betStats[sport][market]
+	if !ok {
+		return nil, false
+	}
+	return strings.Split(stat.expr, " + "), true
+}
+
+// GetSimulationGames retrieves a player's value for each of the given gamelog columns in every
+// game played on or after from (YYYY-MM-DD), oldest first. NBA players are matched by player ID,
+// NFL players by gamelog name. Columns must come from MarketComponents; an NFL game missing from
+// one of the gamelogs counts 0 for that gamelog's columns.
+func GetSimulationGames(db *sql.DB, sport, player string, columns []string, from string) ([]models.StatGame, error) {
+	byTable := map[string][]string{}
+	for _, column := range columns {
+		table, ok := componentTable(sport, column)
+		if !ok {
+			return nil, fmt.Errorf("unknown %s stat column: %s", sport, column)
+		}
+		if !slices.Contains(byTable[table], column) {
+			byTable[table] = append(byTable[table], column)
+		}
+	}
+
+	byGame := map[string]*models.StatGame{}
+	for table, tableColumns := range byTable {
+		selects := make([]string, len(tableColumns))
+		for i, column := range tableColumns {
+			selects[i] = "COALESCE(" + column + "::DOUBLE, 0)"
+		}
+		// NBA box scores list players who didn't play with 0 minutes
+		playerFilter := "player_id::VARCHAR = ? AND COALESCE(minutes_per_game, 0) > 0"
+		gameID := "GAME_ID"
+		if sport == "nfl" {
+			playerFilter = "player_name = ?"
+			gameID = "game_id"
+		}
+		query := `
+			SELECT ` + gameID + `::VARCHAR, CAST(game_date AS DATE)::VARCHAR, ` + strings.Join(selects, ", ") + `
+			FROM ` + table + `
+			WHERE ` + playerFilter + `
+				AND CAST(game_date AS DATE) >= CAST(? AS DATE)
+			QUALIFY ROW_NUMBER() OVER (PARTITION BY ` + gameID + `) = 1
+		`
+		if err := scanSimulationGames(db, query, player, from, tableColumns, byGame); err != nil {
+			return nil, err
+		}
+	}
+
+	games := make([]models.StatGame, 0, len(byGame))
+	for _, game := range byGame {
+		for _, column := range columns {
+			if _, ok := game.Stats[column]; !ok {
+				game.Stats[column] = 0
+			}
+		}
+		games = append(games, *game)
+	}
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].GameDate != games[j].GameDate {
+			return games[i].GameDate < games[j].GameDate
+		}
+		return games[i].GameID < games[j].GameID
+	})
+	return games, nil
+}
+
+func scanSimulationGames(db *sql.DB, query, player, from string, columns []string, byGame map[string]*models.StatGame) error {
+	rows, err := db.Query(query, player, from)
+	if err != nil {
+		return fmt.Errorf("failed to query simulation games: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameID, gameDate string
+		values := make([]float64, len(columns))
+		dest := []any{&gameID, &gameDate}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan simulation game row: %w", err)
+		}
+
+		game := byGame[gameID]
+		if game == nil {
+			game = &models.StatGame{GameID: gameID, GameDate: gameDate, Stats: map[string]float64{}}
+			byGame[gameID] = game
+		}
+		for i, column := range columns {
+			game.Stats[column] = values[i]
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over simulation game rows: %w", err)
+	}
+	return nil
+}
+
+// componentTable finds the gamelog table holding a market component column
+func componentTable(sport, column string) (string, bool) {
+	for _, stat := range betStats[sport] {
+		for _, c := range strings.Split(stat.expr, " + ") {
+			if c == column {
+				return stat.table, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"sports_api/internal/alerts"
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+	"sports_api/internal/stream"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+// AlertHandler handles users' line-movement alert rules and their webhook delivery log
+type AlertHandler struct {
+	db        *sql.DB
+	evaluator *alerts.Evaluator
+}
+
+// NewAlertHandler creates a new AlertHandler instance and starts evaluating the hub's odds
+// changes against the stored rules
+func NewAlertHandler(db *sql.DB, hub *stream.OddsHub) *AlertHandler {
+	if err := database.EnsureAlertTables(db); err != nil {
+		log.Printf("alerts: %v", err)
+	}
+
+	evaluator := alerts.NewEvaluator(
+		func() ([]models.AlertRule, error) { return database.GetAlertRules(db, "") },
+		func(d models.AlertDelivery) error { return database.LogAlertDelivery(db, d) },
+		alerts.NewWebhookClient(15*time.Second),
+	)
+	go evaluator.Run(context.Background(), hub)
+
+	return &AlertHandler{db: db, evaluator: evaluator}
+}
+
+// CreateAlertRule stores a rule for the authenticated user. The response includes the rule's
+// webhook signing secret, generated when the request leaves it out; it isn't returned again.
+func (h *AlertHandler) CreateAlertRule(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	var rule models.AlertRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	rule.UserID = userID
+
+	if err := validateAlertRule(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if rule.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to generate webhook secret",
+				"details": err.Error(),
+			})
+			return
+		}
+		rule.Secret = hex.EncodeToString(secret)
+	}
+
+	rule, err := database.CreateAlertRule(h.db, rule)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+	h.evaluator.Invalidate()
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// GetAlertRules lists the authenticated user's rules without their secrets
+func (h *AlertHandler) GetAlertRules(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+	rules, err := database.GetAlertRules(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve alert rules",
+			"details": err.Error(),
+		})
+		return
+	}
+	for i := range rules {
+		rules[i].Secret = ""
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count": len(rules),
+		"rules": rules,
+	})
+}
+
+// DeleteAlertRule removes one of the authenticated user's rules
+func (h *AlertHandler) DeleteAlertRule(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+	err := database.DeleteAlertRule(h.db, userID, c.Param("alert_id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No alert rule found for ID: " + c.Param("alert_id"),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to delete alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+	h.evaluator.Invalidate()
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAlertDeliveries lists the authenticated user's most recent webhook deliveries, newest first. Optional
+// ?rule_id narrows to one rule and ?limit caps the count.
+func (h *AlertHandler) GetAlertDeliveries(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+	limit := defaultDeliveryLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		n, err := strconv.Atoi(limitStr)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid limit",
+			})
+			return
+		}
+		limit = min(n, maxDeliveryLimit)
+	}
+
+	deliveries, err := database.GetAlertDeliveries(h.db, userID, c.Query("rule_id"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve alert deliveries",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":      len(deliveries),
+		"deliveries": deliveries,
+	})
+}
+
+// validateAlertRule checks a new rule and fills in the default unit (points) and direction (any)
+func validateAlertRule(rule *models.AlertRule) error {
+	if strings.TrimSpace(rule.UserID) == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	rule.Sport = strings.ToLower(strings.TrimSpace(rule.Sport))
+	if rule.Sport != "" && rule.Sport != "nba" && rule.Sport != "nfl" {
+		return fmt.Errorf("sport must be nba or nfl")
+	}
+
+	if rule.Threshold <= 0 {
+		return fmt.Errorf("threshold must be positive")
+	}
+	if rule.ThresholdUnit == "" {
+		rule.ThresholdUnit = "points"
+	}
+	if rule.ThresholdUnit != "points" && rule.ThresholdUnit != "cents" {
+		return fmt.Errorf("threshold_unit must be points or cents")
+	}
+	if rule.Direction == "" {
+		rule.Direction = "any"
+	}
+	if rule.Direction != "up" && rule.Direction != "down" && rule.Direction != "any" {
+		return fmt.Errorf("direction must be up, down or any")
+	}
+
+	u, err := url.Parse(rule.WebhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook_url must be an absolute http or https URL")
+	}
+	// the webhook client refuses internal addresses when it dials; this catches the obvious ones early
+	host := strings.ToLower(u.Hostname())
+	if ip, err := netip.ParseAddr(host); (err == nil && !alerts.PublicAddress(ip)) || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("webhook_url must point at a public address")
+	}
+	return nil
+}
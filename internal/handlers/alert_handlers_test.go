@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAlertRule(t *testing.T) {
+	rule := models.AlertRule{UserID: "u1", Sport: "NBA", Threshold: 1, WebhookURL: "https://example.com/hook"}
+	assert.NoError(t, validateAlertRule(&rule))
+	assert.Equal(t, "nba", rule.Sport)
+	assert.Equal(t, "points", rule.ThresholdUnit)
+	assert.Equal(t, "any", rule.Direction)
+
+	tests := map[string]models.AlertRule{
+		"no threshold": {UserID: "u1", WebhookURL: "https://example.com/hook"},
+		"bad unit":     {UserID: "u1", Threshold: 1, ThresholdUnit: "percent", WebhookURL: "https://example.com/hook"},
+		"bad sport":    {UserID: "u1", Sport: "mlb", Threshold: 1, WebhookURL: "https://example.com/hook"},
+		"bad url":      {UserID: "u1", Threshold: 1, WebhookURL: "example.com/hook"},
+		"bad scheme":   {UserID: "u1", Threshold: 1, WebhookURL: "ftp://example.com/hook"},
+		"loopback":     {UserID: "u1", Threshold: 1, WebhookURL: "http://127.0.0.1:8080/hook"},
+		"metadata":     {UserID: "u1", Threshold: 1, WebhookURL: "http://169.254.169.254/latest/meta-data"},
+		"private":      {UserID: "u1", Threshold: 1, WebhookURL: "http://[fd00::1]/hook"},
+		"localhost":    {UserID: "u1", Threshold: 1, WebhookURL: "http://localhost/hook"},
+		"direction":    {UserID: "u1", Threshold: 1, Direction: "sideways", WebhookURL: "https://example.com/hook"},
+	}
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, validateAlertRule(&rule))
+		})
+	}
+}
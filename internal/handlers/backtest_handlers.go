@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sports_api/internal/backtest"
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+	"sports_api/internal/projection"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxBacktestDays         = 366
+	defaultBacktestLookback = 120
+	defaultBacktestMinGames = 5
+)
+
+// Backtest replays NBA games between ?from and ?to (YYYY-MM-DD), projecting each from the
+// player's earlier games, and reports MAE, calibration, hit rate and simulated ROI against the
+// day's prop line for each market in ?markets (default player_points) and each projection model
+// in ?model (default rolling_average). Optional ?distribution turns projections into over
+// probabilities (default poisson), ?book picks the sportsbook, ?min_games sets the history
+// needed before a player is projected and ?lookback_days how far before ?from that history
+// reaches.
+func (h *NBAHandler) Backtest(c *gin.Context) {
+	from, err := time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid from date, expected YYYY-MM-DD",
+		})
+		return
+	}
+	to, err := time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil || to.Before(from) || to.Sub(from) > maxBacktestDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Invalid to date, expected YYYY-MM-DD on or after from and within %d days", maxBacktestDays),
+		})
+		return
+	}
+
+	markets := listQuery(c, "markets")
+	if len(markets) == 0 {
+		markets = []string{"player_points"}
+	}
+	for _, market := range markets {
+		if !slices.Contains(database.BetMarkets("nba"), market) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid market: " + market,
+				"valid": database.BetMarkets("nba"),
+			})
+			return
+		}
+	}
+
+	projectors, err := projectorsQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	distribution := c.DefaultQuery("distribution", projection.DefaultDistribution)
+	if !slices.Contains(projection.Distributions(), distribution) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid distribution: " + distribution,
+			"valid": projection.Distributions(),
+		})
+		return
+	}
+
+	minGames, err := nonNegativeQuery(c, "min_games", defaultBacktestMinGames)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	lookback, err := nonNegativeQuery(c, "lookback_days", defaultBacktestLookback)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	opts := backtest.Options{
+		Distribution: distribution,
+		From:         from.Format(time.DateOnly),
+		To:           to.Format(time.DateOnly),
+		MinGames:     minGames,
+	}
+	historyFrom := from.AddDate(0, 0, -lookback).Format(time.DateOnly)
+	book := strings.TrimSpace(c.Query("book"))
+
+	reports := []models.BacktestReport{}
+	unmatched := map[string]bool{}
+	for _, market := range markets {
+		stats, err := database.GetNBAMarketGameStats(h.db, market, "", historyFrom, opts.To)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve box scores",
+				"details": err.Error(),
+			})
+			return
+		}
+		lines, err := database.GetNBAHistoricalPropLines(h.db, market, book, opts.From, opts.To)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve prop lines",
+				"details": err.Error(),
+			})
+			return
+		}
+		for i := range lines {
+			id, ok := resolver.Resolve(lines[i].Player)
+			if !ok {
+				unmatched[lines[i].Player] = true
+			}
+			lines[i].PlayerID = id
+		}
+
+		opts.Market = market
+		for _, projector := range projectors {
+			reports = append(reports, backtest.Run(stats, lines, projector, opts))
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports":           reports,
+		"unmatched_players": len(unmatched),
+	})
+}
+
+// nonNegativeQuery reads an optional non-negative integer query param
+func nonNegativeQuery(c *gin.Context, param string, fallback int) (int, error) {
+	v := c.Query(param)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", param, v)
+	}
+	return n, nil
+}
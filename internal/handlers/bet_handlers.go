@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"sports_api/internal/betting"
+	"sports_api/internal/database"
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// betGradeInterval is how often pending bets are checked against the gamelogs
+const betGradeInterval = 15 * time.Minute
+
+// BetHandler handles users' bet ledgers
+type BetHandler struct {
+	db *sql.DB
+}
+
+// NewBetHandler creates a new BetHandler instance and starts grading pending bets in the background
+func NewBetHandler(db *sql.DB) *BetHandler {
+	if err := database.EnsureBetTables(db); err != nil {
+		log.Printf("bets: %v", err)
+	}
+	h := &BetHandler{db: db}
+	go h.runGrading(betGradeInterval)
+	return h
+}
+
+// CreateBet logs a bet for the authenticated user. placed_at defaults to now.
+func (h *BetHandler) CreateBet(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	var bet models.Bet
+	if err := c.ShouldBindJSON(&bet); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	bet.UserID = userID
+
+	if err := validateBet(&bet, time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid bet",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	bet, err := database.CreateBet(h.db, bet)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create bet",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bet)
+}
+
+// GetBets lists the authenticated user's bets
+func (h *BetHandler) GetBets(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+	bets, err := h.bets(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve bets",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count": len(bets),
+		"bets":  bets,
+	})
+}
+
+// GetBetSummary reports the authenticated user's record, ROI and CLV overall and by market and sportsbook
+func (h *BetHandler) GetBetSummary(c *gin.Context) {
+	userID, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+	bets, err := h.bets(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve bets",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, betting.Summarize(bets))
+}
+
+// bets loads a user's bets with CLV filled in
+func (h *BetHandler) bets(userID string) ([]models.Bet, error) {
+	bets, err := database.GetBets(h.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i, bet := range bets {
+		bets[i] = betting.CLV(bet)
+	}
+	return bets, nil
+}
+
+func (h *BetHandler) runGrading(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := h.GradePending(); err != nil {
+			log.Printf("bets: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// GradePending grades every pending bet whose game has reached the gamelogs. A bet that can't be
+// graded yet stays pending; one whose NBA player can't be resolved is voided once the gamelogs
+// have moved past its game.
+func (h *BetHandler) GradePending() error {
+	bets, err := database.GetPendingBets(h.db)
+	if err != nil {
+		return err
+	}
+
+	var resolver *identity.Resolver
+	for _, bet := range bets {
+		player := bet.Player
+		if bet.Sport == "nba" {
+			if resolver == nil {
+				if resolver, err = database.NewNBAPlayerResolver(h.db); err != nil {
+					return err
+				}
+			}
+			id, ok := resolver.Resolve(bet.Player)
+			if !ok {
+				if err := h.voidUnresolved(bet); err != nil {
+					log.Printf("bets: failed to void bet %s: %v", bet.ID, err)
+				}
+				continue
+			}
+			player = id
+		}
+
+		if err := h.grade(bet, player); err != nil {
+			log.Printf("bets: failed to grade bet %s: %v", bet.ID, err)
+		}
+	}
+	return nil
+}
+
+// voidUnresolved voids a bet whose player isn't in the roster or aliases once the gamelogs have
+// moved past its game, when no alias added later can still grade it
+func (h *BetHandler) voidUnresolved(bet models.Bet) error {
+	past, err := database.BetGamelogsPast(h.db, bet.Sport, bet.Market, gradingDate(bet))
+	if err != nil {
+		return err
+	}
+	if !past {
+		log.Printf("bets: bet %s is pending, no player found for %q", bet.ID, bet.Player)
+		return nil
+	}
+	log.Printf("bets: voiding bet %s, no player found for %q", bet.ID, bet.Player)
+	return database.UpdateBetGrade(h.db, betting.Void(bet, time.Now()))
+}
+
+// gradingDate is the date of a bet's game
+func gradingDate(bet models.Bet) string {
+	if bet.GameDate == "" {
+		// bets logged before game dates were recorded
+		return betGameDate(bet.PlacedAt)
+	}
+	return bet.GameDate
+}
+
+// grade settles a bet if its game has finished and stores the result. player is the NBA player ID
+// or NFL gamelog name. A bet whose player has no line on its game date once the gamelogs have
+// moved past it is voided.
+func (h *BetHandler) grade(bet models.Bet, player string) error {
+	gameDate := gradingDate(bet)
+	outcome, ok, err := database.GetBetOutcome(h.db, bet.Sport, bet.Market, player, gameDate)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		past, err := database.BetGamelogsPast(h.db, bet.Sport, bet.Market, gameDate)
+		if err != nil || !past {
+			return err
+		}
+		return database.UpdateBetGrade(h.db, betting.Void(bet, time.Now()))
+	}
+
+	// without a known start there's no telling pre-game snapshots from in-game ones
+	var closing *models.Odds
+	if !outcome.Start.IsZero() {
+		odds, ok, err := database.GetClosingOdds(h.db, bet.Sport, bet.Player, bet.Market, bet.Sportbook, bet.PlacedAt, outcome.Start)
+		if err != nil {
+			return err
+		}
+		if ok {
+			closing = &odds
+		}
+	}
+
+	return database.UpdateBetGrade(h.db, betting.Grade(bet, outcome, closing, time.Now()))
+}
+
+// betGameDate is the Eastern date placedAt falls on, the date the gamelogs list US games under
+func betGameDate(placedAt time.Time) string {
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		placedAt = placedAt.In(loc)
+	}
+	return placedAt.Format(time.DateOnly)
+}
+
+// validateBet checks a new bet, normalizing sport and side, defaulting placed_at to now and
+// game_date to the day it was placed, and dropping any grading fields sent with it
+func validateBet(bet *models.Bet, now time.Time) error {
+	*bet = models.Bet{
+		UserID:    bet.UserID,
+		Sport:     bet.Sport,
+		Player:    bet.Player,
+		Market:    bet.Market,
+		Side:      bet.Side,
+		Line:      bet.Line,
+		Price:     bet.Price,
+		Sportbook: bet.Sportbook,
+		Stake:     bet.Stake,
+		PlacedAt:  bet.PlacedAt,
+		GameDate:  bet.GameDate,
+	}
+
+	bet.Sport = strings.ToLower(strings.TrimSpace(bet.Sport))
+	if bet.Sport != "nba" && bet.Sport != "nfl" {
+		return fmt.Errorf("sport must be nba or nfl")
+	}
+	if strings.TrimSpace(bet.Player) == "" {
+		return fmt.Errorf("player is required")
+	}
+	if markets := database.BetMarkets(bet.Sport); !slices.Contains(markets, bet.Market) {
+		return fmt.Errorf("market must be one of %s", strings.Join(markets, ", "))
+	}
+	bet.Side = strings.ToLower(strings.TrimSpace(bet.Side))
+	if bet.Side != "over" && bet.Side != "under" {
+		return fmt.Errorf("side must be over or under")
+	}
+	if bet.Price > -100 && bet.Price < 100 {
+		return fmt.Errorf("price must be American odds of at least +100 or at most -100")
+	}
+	if strings.TrimSpace(bet.Sportbook) == "" {
+		return fmt.Errorf("sportbook is required")
+	}
+	if bet.Stake <= 0 {
+		return fmt.Errorf("stake must be positive")
+	}
+	if bet.PlacedAt.IsZero() {
+		bet.PlacedAt = now
+	}
+	bet.GameDate = strings.TrimSpace(bet.GameDate)
+	if bet.GameDate == "" {
+		bet.GameDate = betGameDate(bet.PlacedAt)
+	} else if _, err := time.Parse(time.DateOnly, bet.GameDate); err != nil {
+		return fmt.Errorf("game_date must be YYYY-MM-DD")
+	}
+	return nil
+}
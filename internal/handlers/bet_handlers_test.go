@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBet(t *testing.T) {
+	now := time.Date(2025, 1, 14, 18, 0, 0, 0, time.UTC)
+	actual := 30.0
+	bet := models.Bet{
+		UserID: "u1", Sport: "NBA", Player: "Jalen Brunson", Market: "player_points", Side: "Over",
+		Line: 26.5, Price: -115, Sportbook: "FanDuel", Stake: 10, Status: "won", Actual: &actual,
+	}
+	assert.NoError(t, validateBet(&bet, now))
+	assert.Equal(t, "nba", bet.Sport)
+	assert.Equal(t, "over", bet.Side)
+	assert.Equal(t, now, bet.PlacedAt)
+	assert.Equal(t, "2025-01-14", bet.GameDate)
+	assert.Empty(t, bet.Status, "grading fields are dropped")
+	assert.Nil(t, bet.Actual)
+
+	valid := models.Bet{UserID: "u1", Sport: "nfl", Player: "Patrick Mahomes", Market: "player_pass_yds", Side: "under", Line: 265.5, Price: 100, Sportbook: "DraftKings", Stake: 5}
+	tests := map[string]func(*models.Bet){
+		"sport":      func(b *models.Bet) { b.Sport = "mlb" },
+		"market":     func(b *models.Bet) { b.Market = "player_points" },
+		"side":       func(b *models.Bet) { b.Side = "yes" },
+		"price":      func(b *models.Bet) { b.Price = -50 },
+		"stake":      func(b *models.Bet) { b.Stake = 0 },
+		"player":     func(b *models.Bet) { b.Player = " " },
+		"sportsbook": func(b *models.Bet) { b.Sportbook = "" },
+		"game_date":  func(b *models.Bet) { b.GameDate = "01/15/2025" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			bet := valid
+			mutate(&bet)
+			assert.Error(t, validateBet(&bet, now))
+		})
+	}
+
+	bet = valid
+	assert.NoError(t, validateBet(&bet, now))
+
+	// a late-night Eastern bet belongs to that Eastern day, not the UTC one
+	bet = valid
+	bet.PlacedAt = time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC)
+	assert.NoError(t, validateBet(&bet, now))
+	assert.Equal(t, "2025-01-14", bet.GameDate)
+
+	bet = valid
+	bet.GameDate = "2025-01-16"
+	assert.NoError(t, validateBet(&bet, now))
+	assert.Equal(t, "2025-01-16", bet.GameDate)
+}
+
+func TestGradePendingVoidsUnresolvedPlayers(t *testing.T) {
+	db := openFixture(t,
+		`CREATE SCHEMA nba_data`,
+		`CREATE TABLE nba_data.team_roster (PLAYER_ID VARCHAR, PLAYER VARCHAR)`,
+		`INSERT INTO nba_data.team_roster VALUES ('1628973', 'Jalen Brunson')`,
+		`CREATE TABLE nba_data.player_boxscores (game_date DATE, player_id VARCHAR, points INT)`,
+		`INSERT INTO nba_data.player_boxscores VALUES ('2025-01-20', '1628973', 30)`,
+	)
+	require.NoError(t, database.EnsureBetTables(db))
+	h := &BetHandler{db: db}
+
+	placed := time.Date(2025, 1, 14, 18, 0, 0, 0, time.UTC)
+	for _, gameDate := range []string{"2025-01-15", "2025-01-25"} {
+		_, err := database.CreateBet(db, models.Bet{
+			UserID: "u1", Sport: "nba", Player: "Nobody Real", Market: "player_points", Side: "over",
+			Line: 20.5, Price: -110, Sportbook: "FanDuel", Stake: 10, PlacedAt: placed, GameDate: gameDate,
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, h.GradePending())
+
+	bets, err := database.GetBets(db, "u1")
+	require.NoError(t, err)
+	status := map[string]string{}
+	for _, bet := range bets {
+		status[bet.GameDate] = bet.Status
+	}
+	// the gamelogs have moved past the first game but not the second
+	assert.Equal(t, map[string]string{"2025-01-15": "void", "2025-01-25": "pending"}, status)
+}
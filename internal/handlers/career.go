@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// allSeasons is the seasonParam default for endpoints that cover every season when ?season is absent
+func allSeasons() (int, error) {
+	return 0, nil
+}
+
+func rushingRates(s models.NFLPlayerRushingStats) map[string]float64 {
+	return map[string]float64{
+		"rushingYardsPerGame":       s.RushingYardsPerGame,
+		"yardsPerRushAttempt":       s.YardsPerRushAttempt,
+		"rushingAttemptsPerGame":    perGame(s.RushingAttempts, s.TeamGamesPlayed),
+		"yardsFromScrimmagePerGame": s.YardsFromScrimmagePerGame,
+	}
+}
+
+func receivingRates(s models.NFLPlayerReceivingStats) map[string]float64 {
+	return map[string]float64{
+		"receivingYardsPerGame":     s.ReceivingYardsPerGame,
+		"receptionsPerGame":         perGame(s.Receptions, s.TeamGamesPlayed),
+		"targetsPerGame":            perGame(s.ReceivingTargets, s.TeamGamesPlayed),
+		"yardsPerReception":         s.YardsPerReception,
+		"yardsFromScrimmagePerGame": s.YardsFromScrimmagePerGame,
+	}
+}
+
+func passingRates(s models.NFLPlayerPassingStats) map[string]float64 {
+	return map[string]float64{
+		"netPassingYardsPerGame": s.NetPassingYardsPerGame,
+		"completionPct":          s.CompletionPct,
+		"interceptionPct":        s.InterceptionPct,
+		"avgGain":                s.AvgGain,
+	}
+}
+
+func perGame(total, games int) float64 {
+	if games == 0 {
+		return 0
+	}
+	return float64(total) / float64(games)
+}
+
+// seasonDeltas compares each season's rates with the season before it. Seasons must be sorted
+// oldest first; the first season has nothing to compare against and gets no delta.
+func seasonDeltas[T any](seasons []T, season func(T) int, rates func(T) map[string]float64) []models.NFLSeasonDelta {
+	deltas := []models.NFLSeasonDelta{}
+	for i := 1; i < len(seasons); i++ {
+		prev, cur := rates(seasons[i-1]), rates(seasons[i])
+		d := models.NFLSeasonDelta{
+			Season:         season(seasons[i]),
+			PreviousSeason: season(seasons[i-1]),
+			Deltas:         make(map[string]float64, len(cur)),
+		}
+		for k, v := range cur {
+			d.Deltas[k] = v - prev[k]
+		}
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// GetPlayerRushingCareer returns one row of rushing stats per season with season-over-season deltas
+func (h *PlayerHandler) GetPlayerRushingCareer(c *gin.Context) {
+	playerName := c.Param("player")
+	if strings.TrimSpace(playerName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Player name is required",
+		})
+		return
+	}
+
+	seasons, err := database.GetPlayerRushingCareer(h.db, c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve player rushing career",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(seasons) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No rushing stats found for player: " + playerName,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player":  playerName,
+		"seasons": seasons,
+		"deltas":  seasonDeltas(seasons, func(s models.NFLPlayerRushingStats) int { return s.Season }, rushingRates),
+	})
+}
+
+// GetPlayerReceivingCareer returns one row of receiving stats per season with season-over-season deltas
+func (h *PlayerHandler) GetPlayerReceivingCareer(c *gin.Context) {
+	playerName := c.Param("player")
+	if strings.TrimSpace(playerName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Player name is required",
+		})
+		return
+	}
+
+	seasons, err := database.GetPlayerReceivingCareer(h.db, c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve player receiving career",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(seasons) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No receiving stats found for player: " + playerName,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player":  playerName,
+		"seasons": seasons,
+		"deltas":  seasonDeltas(seasons, func(s models.NFLPlayerReceivingStats) int { return s.Season }, receivingRates),
+	})
+}
+
+// GetPlayerPassingCareer returns one row of passing stats per season with season-over-season deltas
+func (h *PlayerHandler) GetPlayerPassingCareer(c *gin.Context) {
+	playerName := c.Param("player")
+	if strings.TrimSpace(playerName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Player name is required",
+		})
+		return
+	}
+
+	seasons, err := database.GetPlayerPassingCareer(h.db, c.Param("player_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve player passing career",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(seasons) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No passing stats found for player: " + playerName,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player":  playerName,
+		"seasons": seasons,
+		"deltas":  seasonDeltas(seasons, func(s models.NFLPlayerPassingStats) int { return s.Season }, passingRates),
+	})
+}
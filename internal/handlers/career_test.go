@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeasonDeltas(t *testing.T) {
+	seasons := []models.NFLPlayerRushingStats{
+		{Season: 2022, RushingYardsPerGame: 60, YardsPerRushAttempt: 4.0, RushingAttempts: 170, TeamGamesPlayed: 17},
+		{Season: 2023, RushingYardsPerGame: 75, YardsPerRushAttempt: 4.5, RushingAttempts: 204, TeamGamesPlayed: 17},
+	}
+
+	deltas := seasonDeltas(seasons, func(s models.NFLPlayerRushingStats) int { return s.Season }, rushingRates)
+
+	assert.Len(t, deltas, 1)
+	assert.Equal(t, 2023, deltas[0].Season)
+	assert.Equal(t, 2022, deltas[0].PreviousSeason)
+	assert.InDelta(t, 15.0, deltas[0].Deltas["rushingYardsPerGame"], 1e-9)
+	assert.InDelta(t, 0.5, deltas[0].Deltas["yardsPerRushAttempt"], 1e-9)
+	assert.InDelta(t, 2.0, deltas[0].Deltas["rushingAttemptsPerGame"], 1e-9)
+}
+
+func TestSeasonDeltas_SingleSeason(t *testing.T) {
+	seasons := []models.NFLPlayerPassingStats{{Season: 2024}}
+	deltas := seasonDeltas(seasons, func(s models.NFLPlayerPassingStats) int { return s.Season }, passingRates)
+	assert.NotNil(t, deltas)
+	assert.Empty(t, deltas)
+}
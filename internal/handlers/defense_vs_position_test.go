@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDefenseVsPositionRejectsInvalidLast(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewNBAHandler(nil)
+
+	for _, last := range []string{"-1", "five"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "season_id", Value: "2024-25"}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/defense-vs-position/2024-25?last="+last, nil)
+
+		h.GetDefenseVsPosition(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, last)
+		assert.Contains(t, w.Body.String(), "Invalid number of games", last)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import "strings"
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, from 0 (nothing in common) to 1 (equal)
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo, hi := max(0, i-window), min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// matchScore rates how well a normalized query matches a normalized name. Exact matches score 1,
+// prefixes of the name or any of its words score 0.9-0.99, and everything else falls back to the
+// best Jaro-Winkler similarity against the full name or a single word.
+func matchScore(query, name string) float64 {
+	if query == "" || name == "" {
+		return 0
+	}
+	if query == name {
+		return 1
+	}
+
+	coverage := float64(len(query)) / float64(len(name))
+	if strings.HasPrefix(name, query) {
+		return 0.9 + 0.09*coverage
+	}
+	for _, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, query) {
+			return 0.9 + 0.08*coverage
+		}
+	}
+
+	best := jaroWinkler(query, name)
+	if !strings.Contains(query, " ") {
+		for _, word := range strings.Fields(name) {
+			best = max(best, jaroWinkler(query, word))
+		}
+	}
+	// keep fuzzy matches below any prefix match
+	return min(best, 0.89)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	assert.InDelta(t, 1.0, jaroWinkler("martha", "martha"), 1e-9)
+	assert.InDelta(t, 0.961, jaroWinkler("martha", "marhta"), 1e-3)
+	assert.InDelta(t, 0.840, jaroWinkler("dwayne", "duane"), 1e-3)
+	assert.Equal(t, 0.0, jaroWinkler("abc", "xyz"))
+}
+
+func TestMatchScore(t *testing.T) {
+	assert.Equal(t, 1.0, matchScore("lebron james", "lebron james"))
+	assert.Greater(t, matchScore("lebr", "lebron james"), 0.9)
+	assert.Greater(t, matchScore("james", "lebron james"), 0.9)
+	assert.Greater(t, matchScore("giannis antetokounpo", "giannis antetokounmpo"), 0.8)
+	assert.Less(t, matchScore("giannis antetokounpo", "giannis antetokounmpo"), 0.9)
+	assert.Less(t, matchScore("zzz", "lebron james"), 0.5)
+}
+
+func TestSearchIndexRank(t *testing.T) {
+	candidates := []models.SearchResult{
+		{Sport: "nba", Type: "player", ID: "203999", Name: "Nikola Jokić", Team: "Denver"},
+		{Sport: "nba", Type: "player", ID: "1628378", Name: "Donovan Mitchell", Team: "Cleveland"},
+		{Sport: "nfl", Type: "player", ID: "00-0036945", Name: "Nikola Jokic Jr.", Team: "DEN"},
+		{Sport: "nba", Type: "team", ID: "1610612743", Name: "Denver", Team: "Denver"},
+	}
+
+	index := newSearchIndex(candidates)
+
+	results := index.rank("jokic", "", "", 10)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "203999", results[0].ID)
+
+	results = index.rank("jokic", "nfl", "", 10)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "nfl", results[0].Sport)
+
+	results = index.rank("denvr", "", "team", 10)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "1610612743", results[0].ID)
+
+	results = index.rank("jokic", "", "", 1)
+	assert.Len(t, results, 1)
+}
+
+func TestSearchReusesIndex(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// a nil database would fail the search if the index were reloaded
+	h := NewSearchHandler(nil)
+	h.index = newSearchIndex([]models.SearchResult{{Sport: "nba", Type: "player", ID: "203999", Name: "Nikola Jokić"}})
+	h.indexedAt = time.Now()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/search?q=jokic", nil)
+	h.Search(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "203999")
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"sports_api/internal/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGamelogFilterQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    database.NFLGamelogFilter
+		wantErr bool
+	}{
+		{name: "no filters", query: ""},
+		{
+			name:  "all filters",
+			query: "?season=2024&week_from=3&week_to=8&opponent=Chiefs&location=HOME",
+			want:  database.NFLGamelogFilter{Season: 2024, WeekFrom: 3, WeekTo: 8, Opponent: "Kansas City Chiefs", HomeAway: "home"},
+		},
+		{name: "opponent abbreviation", query: "?opponent=KC", want: database.NFLGamelogFilter{Opponent: "Kansas City Chiefs"}},
+		{name: "bad season", query: "?season=abc", wantErr: true},
+		{name: "reversed weeks", query: "?week_from=9&week_to=2", wantErr: true},
+		{name: "bad location", query: "?location=neutral", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/gamelog"+tt.query, nil)
+
+			got, err := gamelogFilterQuery(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -30,7 +30,7 @@ func TestHealthCheck(t *testing.T) {
 	// Assert the response
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "healthy")
-	assert.Contains(t, w.Body.String(), "NFL API is running")
+	assert.Contains(t, w.Body.String(), `Sports API (NFL \u0026 NBA) is running`)
 }
 
 func TestGetPlayersByTeam_EmptyTeamName(t *testing.T) {
@@ -45,7 +45,7 @@ func TestGetPlayersByTeam_EmptyTeamName(t *testing.T) {
 	router.GET("/players/:team", handler.GetPlayersByTeam)
 
 	// Create a test request with empty team name
-	req, err := http.NewRequest("GET", "/players/", nil)
+	req, err := http.NewRequest("GET", "/players/%20", nil)
 	assert.NoError(t, err)
 
 	// Create a response recorder
@@ -65,6 +65,7 @@ func TestGetPlayersByTeam_ValidTeamName(t *testing.T) {
 
 	// Create a new Gin router
 	router := gin.New()
+	router.Use(gin.Recovery())
 	
 	// Create a mock handler (we'll use nil for db since we're testing routing)
 	handler := &PlayerHandler{db: nil}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sports_api/internal/database"
+	"sports_api/internal/identity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setParam overwrites a path param, adding it when the route doesn't declare it
+func setParam(c *gin.Context, key, value string) {
+	for i := range c.Params {
+		if c.Params[i].Key == key {
+			c.Params[i].Value = value
+			return
+		}
+	}
+	c.Params = append(c.Params, gin.Param{Key: key, Value: value})
+}
+
+// expandPath fills the :params in an ID route template from the request's path params
+func expandPath(template string, params gin.Params) string {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if key, ok := strings.CutPrefix(segment, ":"); ok {
+			value, _ := params.Get(key)
+			segments[i] = url.PathEscape(value)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// byID serves an ID route: the ID in idParam is resolved to a display name, answering 404 for
+// unknown IDs, and the name is set as nameParam before next runs. next queries by the ID and uses
+// the name only to label its response.
+func byID(idParam, nameParam, entity string, resolve func(id string) (string, error), next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param(idParam)
+		if strings.TrimSpace(id) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": entity + " ID is required",
+			})
+			return
+		}
+
+		name, err := resolve(id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "No " + strings.ToLower(entity) + " found for ID: " + id,
+				"details": err.Error(),
+			})
+			return
+		}
+
+		setParam(c, nameParam, name)
+		next(c)
+	}
+}
+
+// redirectToID keeps a name route working by resolving the name in nameParam to an ID and
+// redirecting to the matching ID route. The query string is preserved and 307 keeps the method.
+func redirectToID(nameParam, idParam, entity, target string, resolve func(name string) (string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param(nameParam)
+		if strings.TrimSpace(name) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": entity + " name is required",
+			})
+			return
+		}
+
+		id, err := resolve(name)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "No " + strings.ToLower(entity) + " found for name: " + name,
+				"details": err.Error(),
+			})
+			return
+		}
+
+		setParam(c, idParam, id)
+		location := expandPath(target, c.Params)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusTemporaryRedirect, location)
+	}
+}
+
+// PlayerByID serves next on an NBA route keyed by :player_id, setting nameParam to the player's name
+func (h *NBAHandler) PlayerByID(nameParam string, next gin.HandlerFunc) gin.HandlerFunc {
+	return byID("player_id", nameParam, "Player", func(id string) (string, error) {
+		return database.GetPlayerNameByID(h.db, id)
+	}, next)
+}
+
+// TeamCityByID serves next on an NBA route keyed by :team_id, setting nameParam to the team's city
+func (h *NBAHandler) TeamCityByID(nameParam string, next gin.HandlerFunc) gin.HandlerFunc {
+	return byID("team_id", nameParam, "Team", func(id string) (string, error) {
+		if team, ok := identity.LookupNBATeam(id); ok {
+			return team.City, nil
+		}
+		city, _, err := database.GetTeamNamesByID(h.db, id)
+		return city, err
+	}, next)
+}
+
+// TeamNameByID serves next on an NBA route keyed by :team_id, setting nameParam to the team's name
+func (h *NBAHandler) TeamNameByID(nameParam string, next gin.HandlerFunc) gin.HandlerFunc {
+	return byID("team_id", nameParam, "Team", func(id string) (string, error) {
+		if team, ok := identity.LookupNBATeam(id); ok {
+			return team.TeamName, nil
+		}
+		_, name, err := database.GetTeamNamesByID(h.db, id)
+		return name, err
+	}, next)
+}
+
+// RedirectPlayerName redirects an NBA name route to the :player_id route template target
+func (h *NBAHandler) RedirectPlayerName(nameParam, target string) gin.HandlerFunc {
+	return redirectToID(nameParam, "player_id", "Player", target, func(name string) (string, error) {
+		return database.GetPlayerIDByName(h.db, name)
+	})
+}
+
+// RedirectTeamName redirects an NBA team route given in any form the team registry accepts
+// (ID, city, nickname, full name or abbreviation) to the :team_id route template target
+func (h *NBAHandler) RedirectTeamName(nameParam, target string) gin.HandlerFunc {
+	return redirectToID(nameParam, "team_id", "Team", target, func(name string) (string, error) {
+		if team, ok := identity.LookupNBATeam(name); ok {
+			return team.TeamID, nil
+		}
+		return database.GetTeamIDByName(h.db, name)
+	})
+}
+
+// PlayerByID serves next on an NFL route keyed by :player_id, setting nameParam to the player's name
+func (h *PlayerHandler) PlayerByID(nameParam string, next gin.HandlerFunc) gin.HandlerFunc {
+	return byID("player_id", nameParam, "Player", func(id string) (string, error) {
+		return database.GetNFLPlayerNameByID(h.db, id)
+	}, next)
+}
+
+// RedirectPlayerName redirects an NFL name route to the :player_id route template target
+func (h *PlayerHandler) RedirectPlayerName(nameParam, target string) gin.HandlerFunc {
+	return redirectToID(nameParam, "player_id", "Player", target, func(name string) (string, error) {
+		return database.GetNFLPlayerIDByName(h.db, name)
+	})
+}
+
+// RedirectPasserName redirects an NFL play-by-play passing route to the :player_id route template
+// target, accepting the passer's abbreviated play-by-play name as well as their roster name
+func (h *PlayerHandler) RedirectPasserName(nameParam, target string) gin.HandlerFunc {
+	return redirectToID(nameParam, "player_id", "Player", target, func(name string) (string, error) {
+		return database.GetNFLPasserIDByName(h.db, name)
+	})
+}
+
+// TeamByID serves next on an NFL route keyed by :team_id, setting nameParam to the team's name
+func (h *PlayerHandler) TeamByID(nameParam string, next gin.HandlerFunc) gin.HandlerFunc {
+	return byID("team_id", nameParam, "Team", func(id string) (string, error) {
+		if team, ok := identity.LookupNFLTeam(id); ok {
+			return team.TeamName, nil
+		}
+		return database.GetNFLTeamNameByID(h.db, id)
+	}, next)
+}
+
+// RedirectTeamName redirects an NFL team route given in any form the team registry accepts
+// (ID, city, nickname, full name or abbreviation) to the :team_id route template target
+func (h *PlayerHandler) RedirectTeamName(nameParam, target string) gin.HandlerFunc {
+	return redirectToID(nameParam, "team_id", "Team", target, func(name string) (string, error) {
+		if team, ok := identity.LookupNFLTeam(name); ok {
+			return team.TeamID, nil
+		}
+		return database.GetNFLTeamIDByName(h.db, name)
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPath(t *testing.T) {
+	params := gin.Params{
+		{Key: "player_id", Value: "1629029"},
+		{Key: "season_id", Value: "2024-25"},
+	}
+	assert.Equal(t, "/api/v1/nba/players/id/1629029/shotchart/2024-25",
+		expandPath("/api/v1/nba/players/id/:player_id/shotchart/:season_id", params))
+}
+
+func TestRedirectToID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ids := map[string]string{"De'Aaron Fox": "1628368"}
+	resolve := func(name string) (string, error) {
+		if id, ok := ids[name]; ok {
+			return id, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	router := gin.New()
+	router.GET("/headline-stats/:player_name",
+		redirectToID("player_name", "player_id", "Player", "/players/id/:player_id/headline-stats", resolve))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/headline-stats/De%27Aaron%20Fox?season=2024-25", nil))
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	assert.Equal(t, "/players/id/1628368/headline-stats?season=2024-25", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/headline-stats/Nobody", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resolve := func(id string) (string, error) {
+		if id == "1628368" {
+			return "De'Aaron Fox", nil
+		}
+		return "", errors.New("not found")
+	}
+	next := func(c *gin.Context) {
+		c.String(http.StatusOK, c.Param("player_name"))
+	}
+
+	router := gin.New()
+	router.GET("/players/id/:player_id/headline-stats", byID("player_id", "player_name", "Player", resolve, next))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/players/id/1628368/headline-stats", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "De'Aaron Fox", w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/players/id/42/headline-stats", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNFLTeamIDRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &PlayerHandler{}
+	next := func(c *gin.Context) {
+		c.String(http.StatusOK, c.Param("team_id")+" "+c.Param("team"))
+	}
+
+	router := gin.New()
+	router.GET("/team-snaps/:team", h.RedirectTeamName("team", "/teams/:team_id/snaps"))
+	router.GET("/teams/:team_id/snaps", h.TeamByID("team", next))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/team-snaps/Chiefs?season=2024", nil))
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	assert.Equal(t, "/teams/KC/snaps?season=2024", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/teams/KC/snaps", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "KC Kansas City Chiefs", w.Body.String())
+}
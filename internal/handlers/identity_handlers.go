@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"sports_api/internal/database"
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnmatchedPlayerNames reports, per source, the player names that don't resolve to a rostered
+// player through the normalization rules and alias table. Optional ?source limits the report.
+func (h *NBAHandler) GetUnmatchedPlayerNames(c *gin.Context) {
+	sources := database.NBAPlayerNameSources()
+	if source := strings.ToLower(strings.TrimSpace(c.Query("source"))); source != "" {
+		if !slices.Contains(sources, source) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Unknown source: " + source,
+			})
+			return
+		}
+		sources = []string{source}
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	report := make([]models.UnmatchedNames, 0, len(sources))
+	for _, source := range sources {
+		names, err := database.GetNBASourcePlayerNames(h.db, source)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve " + source + " player names",
+				"details": err.Error(),
+			})
+			return
+		}
+		unmatched := resolver.Unmatched(names)
+		report = append(report, models.UnmatchedNames{
+			Source:    source,
+			Total:     len(names),
+			Count:     len(unmatched),
+			Unmatched: unmatched,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sources": report,
+	})
+}
+
+// ResolvePlayerName returns the player ID a name from any source resolves to
+func (h *NBAHandler) ResolvePlayerName(c *gin.Context) {
+	name := c.Query("name")
+	if strings.TrimSpace(name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Player name is required",
+		})
+		return
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	playerID, ok := resolver.Resolve(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":     "No player found for name: " + name,
+			"canonical": identity.Canonical(name),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"name":      name,
+		"canonical": identity.Canonical(name),
+		"player_id": playerID,
+	})
+}
+
+// AddPlayerAlias adds an alias for a name the normalization rules can't resolve. The source must
+// be one of NBAPlayerNameSources and the player must be on the roster.
+func (h *NBAHandler) AddPlayerAlias(c *gin.Context) {
+	var alias models.PlayerAlias
+	if err := c.ShouldBindJSON(&alias); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	alias.Source = strings.ToLower(strings.TrimSpace(alias.Source))
+	alias.Alias = strings.TrimSpace(alias.Alias)
+	alias.PlayerID = strings.TrimSpace(alias.PlayerID)
+	if !slices.Contains(database.NBAPlayerNameSources(), alias.Source) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Unknown source: " + alias.Source,
+			"valid": database.NBAPlayerNameSources(),
+		})
+		return
+	}
+	if alias.Alias == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Alias is required",
+		})
+		return
+	}
+
+	roster, err := database.GetNBARosterNames(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+	if _, ok := roster[alias.PlayerID]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No rostered player found for ID: " + alias.PlayerID,
+		})
+		return
+	}
+
+	if err := database.AddNBAPlayerAlias(h.db, alias.Source, alias.Alias, alias.PlayerID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to add player alias",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, alias)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sports_api/internal/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPlayerAlias(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openFixture(t,
+		`CREATE SCHEMA nba_data`,
+		`CREATE TABLE nba_data.team_roster (PLAYER_ID VARCHAR, PLAYER VARCHAR)`,
+		`INSERT INTO nba_data.team_roster VALUES ('1629029', 'Luka Dončić')`,
+	)
+	require.NoError(t, database.EnsureIdentityTables(db))
+	h := NewNBAHandler(db)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "rostered player", body: `{"source": "Odds", "alias": "Luka D.", "player_id": "1629029"}`, wantCode: http.StatusCreated},
+		{name: "unknown source", body: `{"source": "twitter", "alias": "Luka D.", "player_id": "1629029"}`, wantCode: http.StatusBadRequest},
+		{name: "unknown player", body: `{"source": "odds", "alias": "Somebody", "player_id": "42"}`, wantCode: http.StatusBadRequest},
+		{name: "blank alias", body: `{"source": "odds", "alias": " ", "player_id": "1629029"}`, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/identity/aliases", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.AddPlayerAlias(c)
+
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+		})
+	}
+
+	aliases, err := database.GetNBAPlayerAliases(db)
+	require.NoError(t, err)
+	assert.Len(t, aliases, 1)
+}
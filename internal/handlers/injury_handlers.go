@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"sports_api/internal/database"
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetInjuries returns the latest injury report for every team, or one team with ?team
+func (h *NBAHandler) GetInjuries(c *gin.Context) {
+	injuries, err := database.GetNBALatestInjuries(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve injuries",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	team := strings.TrimSpace(c.Query("team"))
+	filtered := []models.NBAInjuryStatus{}
+	for _, injury := range injuries {
+		if team != "" && !sameNBATeam(injury.Team, team) {
+			continue
+		}
+		injury.PlayerID, _ = resolver.Resolve(injury.PlayerName)
+		filtered = append(filtered, injury)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":    len(filtered),
+		"injuries": filtered,
+	})
+}
+
+// GetInjuryHistory returns each player's status across injury report ingestions. Optional
+// ?team and ?player narrow the result; player names match in any spelling the identity
+// resolver accepts.
+func (h *NBAHandler) GetInjuryHistory(c *gin.Context) {
+	rows, err := database.GetNBAInjuryHistory(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve injury history",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	team := strings.TrimSpace(c.Query("team"))
+	player := strings.TrimSpace(c.Query("player"))
+	playerID, _ := resolver.Resolve(player)
+
+	filtered := rows[:0]
+	for _, row := range rows {
+		if team != "" && !sameNBATeam(row.Team, team) {
+			continue
+		}
+		row.PlayerID, _ = resolver.Resolve(row.PlayerName)
+		if player != "" && !samePlayer(row, player, playerID) {
+			continue
+		}
+		filtered = append(filtered, row)
+	}
+
+	history := groupInjuryHistory(filtered)
+	c.JSON(http.StatusOK, gin.H{
+		"count":   len(history),
+		"players": history,
+	})
+}
+
+// GetInjuryChanges returns the players whose status changed between each team's two most recent
+// injury reports, newest first, so late scratches surface as soon as they're ingested
+func (h *NBAHandler) GetInjuryChanges(c *gin.Context) {
+	changes, err := database.GetNBAInjuryChanges(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve injury changes",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	team := strings.TrimSpace(c.Query("team"))
+	filtered := []models.NBAInjuryChange{}
+	for _, change := range changes {
+		if team != "" && !sameNBATeam(change.Team, team) {
+			continue
+		}
+		change.PlayerID, _ = resolver.Resolve(change.PlayerName)
+		filtered = append(filtered, change)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":   len(filtered),
+		"changes": filtered,
+	})
+}
+
+// samePlayer matches an injury row against a requested player, by resolved ID when both sides
+// resolve and by canonical name otherwise
+func samePlayer(row models.NBAInjuryStatus, player, playerID string) bool {
+	if playerID != "" && row.PlayerID != "" {
+		return playerID == row.PlayerID
+	}
+	return identity.Canonical(row.PlayerName) == identity.Canonical(player)
+}
+
+// groupInjuryHistory collapses injury rows into one history per team and player, keeping the
+// rows' order within each history and the order each player first appears
+func groupInjuryHistory(rows []models.NBAInjuryStatus) []models.NBAInjuryHistory {
+	history := []models.NBAInjuryHistory{}
+	index := make(map[[2]string]int)
+	for _, row := range rows {
+		key := [2]string{row.Team, row.PlayerName}
+		i, ok := index[key]
+		if !ok {
+			i = len(history)
+			index[key] = i
+			history = append(history, models.NBAInjuryHistory{
+				PlayerID:   row.PlayerID,
+				PlayerName: row.PlayerName,
+				Team:       row.Team,
+			})
+		}
+		history[i].History = append(history[i].History, models.NBAInjuryStatusEntry{
+			Status:       row.Status,
+			IngestedDate: row.IngestedDate,
+		})
+	}
+	return history
+}
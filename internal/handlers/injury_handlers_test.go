@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupInjuryHistory(t *testing.T) {
+	rows := []models.NBAInjuryStatus{
+		{PlayerID: "1", PlayerName: "Jayson Tatum", Team: "BOS", Status: "Questionable", IngestedDate: "2025-01-01"},
+		{PlayerID: "1", PlayerName: "Jayson Tatum", Team: "BOS", Status: "Out", IngestedDate: "2025-01-02"},
+		{PlayerID: "2", PlayerName: "Jrue Holiday", Team: "BOS", Status: "Probable", IngestedDate: "2025-01-02"},
+	}
+
+	history := groupInjuryHistory(rows)
+
+	assert.Len(t, history, 2)
+	assert.Equal(t, "Jayson Tatum", history[0].PlayerName)
+	assert.Equal(t, []models.NBAInjuryStatusEntry{
+		{Status: "Questionable", IngestedDate: "2025-01-01"},
+		{Status: "Out", IngestedDate: "2025-01-02"},
+	}, history[0].History)
+	assert.Len(t, history[1].History, 1)
+}
+
+func TestSamePlayer(t *testing.T) {
+	row := models.NBAInjuryStatus{PlayerName: "Jackson Jr., Jaren"}
+	assert.True(t, samePlayer(row, "Jaren Jackson Jr.", ""))
+	assert.False(t, samePlayer(row, "Jaren Jones", ""))
+
+	row.PlayerID = "1628991"
+	assert.True(t, samePlayer(row, "JJJ", "1628991"))
+	assert.False(t, samePlayer(row, "Jaren Jackson Jr.", "999"))
+}
@@ -2,19 +2,32 @@ package handlers
 
 import (
 	"database/sql"
-	"math"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"regexp"
 	"sports_api/internal/database"
+	"sports_api/internal/identity"
 	"sports_api/internal/models"
+	"sports_api/internal/projection"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+var nbaSeasonPattern = regexp.MustCompile(`^\d{4}-\d{2}$`)
+
 // NBAHandler handles NBA-related HTTP requests
 type NBAHandler struct {
 	db *sql.DB
+
+	factorsMu sync.Mutex
+	factors   map[string]float64
+	factorsAt time.Time
 }
 
 // NewNBAHandler creates a new NBAHandler instance
@@ -35,7 +48,7 @@ func (h *NBAHandler) GetNBAPlayersByTeam(c *gin.Context) {
 	}
 
 	// Get players from database
-	players, err := database.GetNBAPlayersByTeam(h.db, teamCity)
+	players, err := database.GetNBAPlayersByTeam(h.db, c.Param("team_id"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve NBA players",
@@ -52,7 +65,7 @@ func (h *NBAHandler) GetNBAPlayersByTeam(c *gin.Context) {
 	})
 }
 
-// GetNBATeams retrieves all NBA teams
+// GetNBATeams retrieves all NBA teams with their ID, city, nickname, full name and abbreviation
 func (h *NBAHandler) GetNBATeams(c *gin.Context) {
 	teams, err := database.GetNBATeams(h.db)
 	if err != nil {
@@ -63,13 +76,20 @@ func (h *NBAHandler) GetNBATeams(c *gin.Context) {
 		return
 	}
 
+	// The roster only carries the ID and city; fill in the rest from the team registry
+	for i, team := range teams {
+		if registered, ok := identity.LookupNBATeam(team.TeamID); ok {
+			teams[i] = registered
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"count": len(teams),
 		"teams": teams,
 	})
 }
 
-// GetPlayerLastXGames retrieves a player's last X games
+// GetPlayerLastXGames retrieves a player's last X games in ?season, defaulting to the current season
 func (h *NBAHandler) GetPlayerLastXGames(c *gin.Context) {
 	playerName := c.Param("name")
 	lastXGamesStr := c.Param("last_number_of_games")
@@ -90,8 +110,12 @@ func (h *NBAHandler) GetPlayerLastXGames(c *gin.Context) {
 		return
 	}
 
-	// Get player game logs
-	gameLogs, err := database.GetPlayerLastXGames(h.db, playerName, lastXGames)
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	gameLogs, err := database.GetPlayerLastXGames(h.db, c.Param("player_id"), seasonID, lastXGames)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player game logs",
@@ -110,7 +134,7 @@ func (h *NBAHandler) GetPlayerLastXGames(c *gin.Context) {
 	c.JSON(http.StatusOK, gameLogs)
 }
 
-// GetTeamLastXGames retrieves a team's last X games
+// GetTeamLastXGames retrieves a team's last X games in ?season, defaulting to the current season
 func (h *NBAHandler) GetTeamLastXGames(c *gin.Context) {
 	teamCity := c.Param("city")
 	lastXGamesStr := c.Param("number_of_days")
@@ -131,8 +155,12 @@ func (h *NBAHandler) GetTeamLastXGames(c *gin.Context) {
 		return
 	}
 
-	// Get team game logs
-	gameLogs, err := database.GetTeamLastXGames(h.db, teamCity, lastXGames)
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	gameLogs, err := database.GetTeamLastXGames(h.db, c.Param("team_id"), seasonID, lastXGames)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve team game logs",
@@ -164,7 +192,7 @@ func (h *NBAHandler) GetTeamRoster(c *gin.Context) {
 	}
 
 	// Get team roster
-	players, err := database.GetNBAPlayersByTeam(h.db, teamCity)
+	players, err := database.GetNBAPlayersByTeam(h.db, c.Param("team_id"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve team roster",
@@ -209,8 +237,13 @@ func (h *NBAHandler) GetTeamDefenseStats(c *gin.Context) {
 		return
 	}
 
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
 	// Get team defense stats
-	stats, err := database.GetTeamDefenseStats(h.db, teamName)
+	stats, err := database.GetTeamDefenseStats(h.db, c.Param("team_id"), seasonID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve team defense stats",
@@ -235,8 +268,13 @@ func (h *NBAHandler) GetTeamOffenseStats(c *gin.Context) {
 		return
 	}
 
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
 	// Get team defense stats
-	stats, err := database.GetTeamOffenseStats(h.db, teamName)
+	stats, err := database.GetTeamOffenseStats(h.db, c.Param("team_id"), seasonID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve team defense stats",
@@ -262,8 +300,13 @@ func (h *NBAHandler) GetPlayerShootingSplits(c *gin.Context) {
 		return
 	}
 
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
 	// Get player shooting splits
-	splits, err := database.GetPlayerShootingSplits(h.db, playerName)
+	splits, err := database.GetPlayerShootingSplits(h.db, c.Param("player_id"), playerName, seasonID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player shooting splits",
@@ -289,8 +332,13 @@ func (h *NBAHandler) GetPlayerHeadlineStats(c *gin.Context) {
 		return
 	}
 
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
 	// Get player headline stats
-	stats, err := database.GetPlayerHeadlineStats(h.db, playerName)
+	stats, err := database.GetPlayerHeadlineStats(h.db, c.Param("player_id"), playerName, seasonID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player headline stats",
@@ -304,65 +352,70 @@ func (h *NBAHandler) GetPlayerHeadlineStats(c *gin.Context) {
 	})
 }
 
-// PointsPrediction predicts player points (placeholder implementation)
+// PointsPrediction projects a player's points in their next game from their games over the past
+// year, using the model in ?model (default rolling_average). The optional body gives the expected
+// minutes and the opponent, for models that use them.
 func (h *NBAHandler) PointsPrediction(c *gin.Context) {
-	_ = c.Param("player_name") // Using player name parameter but not implementing prediction logic yet
-
 	var playerModel models.PlayerModel
-	if err := c.ShouldBindJSON(&playerModel); err != nil {
+	if err := c.ShouldBindJSON(&playerModel); err != nil && !errors.Is(err, io.EOF) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Invalid request body",
 		})
 		return
 	}
 
-	// This is a placeholder implementation
-	// In a real scenario, you would load a trained model and make predictions
-	// For now, we'll return a simple calculation based on minutes
-	projectedPoints := playerModel.Minutes * 0.5 // Simple placeholder calculation
-
-	c.JSON(http.StatusOK, gin.H{
-		"projected_points": projectedPoints,
-	})
-}
-
-// GetPoissonDistribution calculates Poisson distribution probabilities
-func (h *NBAHandler) GetPoissonDistribution(c *gin.Context) {
-	var poissonDist models.PoissonDist
-	if err := c.ShouldBindJSON(&poissonDist); err != nil {
+	projectors, err := projectorsQuery(c)
+	if err == nil && len(projectors) != 1 {
+		err = fmt.Errorf("only one model can be projected at a time")
+	}
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
+			"error": err.Error(),
 		})
 		return
 	}
+	projector := projectors[0]
 
-	// Calculate Poisson distribution
-	_ = math.Round(poissonDist.PredictedPoints) // Using projected points but not implementing full calculation yet
-	_ = poissonDist.BookLine                    // Using book line but not implementing full calculation yet
-
-	// Simple Poisson calculation (placeholder)
-	// In a real implementation, you would use proper Poisson distribution
-	lessThan := 0.4    // Placeholder
-	greaterThan := 0.6 // Placeholder
-
-	c.JSON(http.StatusOK, gin.H{
-		"less":    lessThan,
-		"greater": greaterThan,
-	})
-}
-
-// GetScoreboard retrieves live scoreboard (placeholder implementation)
-func (h *NBAHandler) GetScoreboard(c *gin.Context) {
-	scoreboard, err := database.GetScoreboard(h.db)
+	to := time.Now()
+	from := to.AddDate(-1, 0, 0).Format(time.DateOnly)
+	stats, err := database.GetNBAMarketGameStats(h.db, "player_points", c.Param("player_id"), from, to.Format(time.DateOnly))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve player shot chart stats",
+			"error":   "Failed to retrieve player games",
 			"details": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, scoreboard)
+	in := projection.Input{Minutes: playerModel.Minutes}
+	for _, s := range stats {
+		in.History = append(in.History, projection.Game{Date: s.GameDate, Opponent: s.Opponent, Value: s.Value, Minutes: s.Minutes})
+	}
+	if projection.Uses(projector, "opponent") && playerModel.OppCity != "" {
+		factors, err := h.opponentFactors()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve league games",
+				"details": err.Error(),
+			})
+			return
+		}
+		in.OpponentFactor = factors[nbaTeamParam(playerModel.OppCity).TeamID]
+	}
+
+	projectedPoints, ok := projector.Project(in)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": fmt.Sprintf("Not enough games in the past year to project %s", c.Param("player_name")),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projected_points": projectedPoints,
+		"model":            projector.Info().Name,
+		"games":            len(in.History),
+	})
 }
 
 func (h *NBAHandler) GetPlayerShotChartStats(c *gin.Context) {
@@ -386,7 +439,7 @@ func (h *NBAHandler) GetPlayerShotChartStats(c *gin.Context) {
 	}
 
 	// Get player shot chart stats
-	shots, err := database.GetPlayerShotChartStats(h.db, playerName, seasonID)
+	shots, err := database.GetPlayerShotChartStats(h.db, c.Param("player_id"), seasonID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player shot chart stats",
@@ -415,7 +468,7 @@ func (h *NBAHandler) GetPlayerAvgShotChartStats(c *gin.Context) {
 	}
 
 	// Get player avg shot chart stats
-	stats, err := database.GetPlayerAvgShotChartStats(h.db, playerName, seasonID)
+	stats, err := database.GetPlayerAvgShotChartStats(h.db, c.Param("player_id"), seasonID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player avg shot chart stats",
@@ -444,7 +497,7 @@ func (h *NBAHandler) GetOpponentShootingByZone(c *gin.Context) {
 		return
 	}
 
-	zones, err := database.GetOpponentZonesByTeamSeason(h.db, opponent, season)
+	zones, err := database.GetOpponentZonesByTeamSeason(h.db, c.Param("team_id"), season)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Status:  "error",
@@ -493,6 +546,7 @@ func (h *NBAHandler) GetMoneylineOdds(c *gin.Context) {
 		return
 	}
 
+	team = nbaTeamParam(team).TeamName
 	odds, err := database.GetMoneylineOdds(h.db, team)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -503,3 +557,90 @@ func (h *NBAHandler) GetMoneylineOdds(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, odds)
 }
+
+// GetDefenseVsPosition returns per-game points, rebounds, assists and threes each team allows
+// to guards, forwards and centers. Optional query params: last, position, team, sort, order
+func (h *NBAHandler) GetDefenseVsPosition(c *gin.Context) {
+	seasonID := strings.TrimSpace(c.Param("season_id"))
+	position := strings.ToUpper(strings.TrimSpace(c.Query("position")))
+	teamName := strings.TrimSpace(c.Query("team"))
+
+	lastXGames, err := strconv.Atoi(c.DefaultQuery("last", "0"))
+	if err != nil || lastXGames < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid number of games",
+		})
+		return
+	}
+
+	stats, err := database.GetNBADefenseVsPosition(h.db, seasonID, lastXGames)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve defense vs position stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Filter after the query so ranks stay league-wide
+	filtered := stats[:0]
+	for _, s := range stats {
+		if position != "" && s.Position != position {
+			continue
+		}
+		if teamName != "" && !sameNBATeam(s.TeamName, teamName) {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+
+	if sortField := c.Query("sort"); sortField != "" {
+		if err := sortByJSONField(filtered, sortField, strings.EqualFold(c.Query("order"), "desc")); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid sort parameter",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season": seasonID,
+		"last":   lastXGames,
+		"count":  len(filtered),
+		"stats":  filtered,
+	})
+}
+
+// seasonQuery reads the optional ?season query param (e.g. "2024-25"), defaulting to the current season.
+// On failure it has already written the error response.
+func (h *NBAHandler) seasonQuery(c *gin.Context) (string, bool) {
+	return seasonParam(c, parseNBASeason, func() (string, error) {
+		return database.GetNBACurrentSeason(h.db)
+	})
+}
+
+// parseNBASeason checks a season ID such as 2024-25
+func parseNBASeason(s string) (string, error) {
+	if !nbaSeasonPattern.MatchString(s) {
+		return "", fmt.Errorf("season must look like 2024-25, got %s", s)
+	}
+	return s, nil
+}
+
+// GetNBASeasons lists the seasons available in the data
+func (h *NBAHandler) GetNBASeasons(c *gin.Context) {
+	seasons, err := database.GetNBASeasons(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve NBA seasons",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":   len(seasons),
+		"seasons": seasons,
+	})
+}
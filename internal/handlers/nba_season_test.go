@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNBASeasonQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewNBAHandler(nil)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/defense-stats/Celtics?season=2023-24", nil)
+	season, ok := h.seasonQuery(c)
+	assert.True(t, ok)
+	assert.Equal(t, "2023-24", season)
+
+	w := httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/defense-stats/Celtics?season=2024", nil)
+	_, ok = h.seasonQuery(c)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
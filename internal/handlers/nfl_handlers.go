@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
 	"sports_api/internal/database"
+	"sports_api/internal/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,7 +38,7 @@ func (h *PlayerHandler) GetPlayersByTeam(c *gin.Context) {
 	}
 
 	// Get players from database
-	players, err := database.GetPlayersByTeam(h.db, teamName)
+	players, err := database.GetPlayersByTeam(h.db, c.Param("team_id"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve players",
@@ -82,8 +85,13 @@ func (h *PlayerHandler) GetPlayerRushingStats(c *gin.Context) {
 		return
 	}
 
+	season, ok := seasonParam(c, parseNFLSeason, allSeasons)
+	if !ok {
+		return
+	}
+
 	// Gin automatically URL-decodes the parameter, so "James%20Connor" becomes "James Connor"
-	stats, err := database.GetPlayerRushingStats(h.db, playerName)
+	stats, err := database.GetPlayerRushingStats(h.db, c.Param("player_id"), season)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player rushing stats",
@@ -94,6 +102,7 @@ func (h *PlayerHandler) GetPlayerRushingStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"player": playerName,
+		"season": stats.Season,
 		"stats":  stats,
 	})
 }
@@ -109,8 +118,13 @@ func (h *PlayerHandler) GetPlayerReceivingStats(c *gin.Context) {
 		return
 	}
 
+	season, ok := seasonParam(c, parseNFLSeason, allSeasons)
+	if !ok {
+		return
+	}
+
 	// Gin automatically URL-decodes the parameter, so "James%20Connor" becomes "James Connor"
-	stats, err := database.GetPlayerReceivingStats(h.db, playerName)
+	stats, err := database.GetPlayerReceivingStats(h.db, c.Param("player_id"), season)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player receiving stats",
@@ -121,6 +135,7 @@ func (h *PlayerHandler) GetPlayerReceivingStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"player": playerName,
+		"season": stats.Season,
 		"stats":  stats,
 	})
 }
@@ -136,8 +151,13 @@ func (h *PlayerHandler) GetPlayerPassingStats(c *gin.Context) {
 		return
 	}
 
+	season, ok := seasonParam(c, parseNFLSeason, allSeasons)
+	if !ok {
+		return
+	}
+
 	// Gin automatically URL-decodes the parameter, so "James%20Connor" becomes "James Connor"
-	stats, err := database.GetPlayerPassingStats(h.db, playerName)
+	stats, err := database.GetPlayerPassingStats(h.db, c.Param("player_id"), season)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player passing stats",
@@ -148,21 +168,32 @@ func (h *PlayerHandler) GetPlayerPassingStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"player": playerName,
+		"season": stats.Season,
 		"stats":  stats,
 	})
 }
 
 func (h *PlayerHandler) GetRushingGameStats(c *gin.Context) {
 	playerName := c.Param("player")
-	slog.Info("Getting rushing game stats for player: %s", playerName)
+	slog.Info("Getting rushing game stats", "player", playerName)
 	// Validate player name
 	if strings.TrimSpace(playerName) == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Player name is required",
 		})
+		return
+	}
+
+	filter, err := gamelogFilterQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid gamelog filter",
+			"details": err.Error(),
+		})
+		return
 	}
 
-	stats, err := database.GetRushingGameStats(h.db, playerName)
+	stats, err := database.GetRushingGameStats(h.db, c.Param("player_id"), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player rushing game stats",
@@ -178,15 +209,25 @@ func (h *PlayerHandler) GetRushingGameStats(c *gin.Context) {
 
 func (h *PlayerHandler) GetPassingGameStats(c *gin.Context) {
 	playerName := c.Param("player")
-	slog.Info("Getting passing game stats for player: %s", playerName)
+	slog.Info("Getting passing game stats", "player", playerName)
 	// Validate player name
 	if strings.TrimSpace(playerName) == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Player name is required",
 		})
+		return
+	}
+
+	filter, err := gamelogFilterQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid gamelog filter",
+			"details": err.Error(),
+		})
+		return
 	}
 
-	stats, err := database.GetPassingGameStats(h.db, playerName)
+	stats, err := database.GetPassingGameStats(h.db, c.Param("player_id"), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve player passing game stats",
@@ -200,6 +241,38 @@ func (h *PlayerHandler) GetPassingGameStats(c *gin.Context) {
 	})
 }
 
+// gamelogFilterQuery reads the optional ?season, ?week_from, ?week_to, ?opponent and ?location
+// (home or away) gamelog filters
+func gamelogFilterQuery(c *gin.Context) (database.NFLGamelogFilter, error) {
+	var filter database.NFLGamelogFilter
+	for param, dest := range map[string]*int{
+		"season":    &filter.Season,
+		"week_from": &filter.WeekFrom,
+		"week_to":   &filter.WeekTo,
+	} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return filter, fmt.Errorf("invalid %s: %s", param, v)
+			}
+			*dest = n
+		}
+	}
+	if filter.WeekFrom > 0 && filter.WeekTo > 0 && filter.WeekFrom > filter.WeekTo {
+		return filter, fmt.Errorf("week_from %d is after week_to %d", filter.WeekFrom, filter.WeekTo)
+	}
+
+	if opponent := c.Query("opponent"); opponent != "" {
+		// the gamelog stores opponents by full team name
+		filter.Opponent = nflTeamParam(opponent).TeamName
+	}
+	filter.HomeAway = strings.ToLower(c.Query("location"))
+	if filter.HomeAway != "" && filter.HomeAway != "home" && filter.HomeAway != "away" {
+		return filter, fmt.Errorf("location must be home or away")
+	}
+	return filter, nil
+}
+
 func (h *PlayerHandler) GetTeamDefenseStats(c *gin.Context) {
 	teamName := c.Param("team")
 	stats, err := database.GetNFLTeamDefenseStats(h.db, teamName)
@@ -208,6 +281,7 @@ func (h *PlayerHandler) GetTeamDefenseStats(c *gin.Context) {
 			"error":   "Failed to retrieve team defense stats",
 			"details": err.Error(),
 		})
+		return
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"stats": stats,
@@ -219,9 +293,10 @@ func (h *PlayerHandler) GetTeamOffenseStats(c *gin.Context) {
 	stats, err := database.GetNFLTeamOffenseStats(h.db, teamName)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve team defense stats",
+			"error":   "Failed to retrieve team offense stats",
 			"details": err.Error(),
 		})
+		return
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"stats": stats,
@@ -239,7 +314,7 @@ func (h *PlayerHandler) GetNFLPassingPBPStats(c *gin.Context) {
 		})
 		return
 	}
-	stats, err := database.GetNFLPassingPBPStats(h.db, playerName, season)
+	stats, err := database.GetNFLPassingPBPStats(h.db, c.Param("player_id"), season)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve passing PBP stats",
@@ -275,3 +350,488 @@ func (h *PlayerHandler) GetNFLPropOdds(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, odds)
 }
+
+// GetTeamDefenseRankings lists defensive stats for every team, sortable by any metric
+// via ?sort=<field>&order=asc|desc
+func (h *PlayerHandler) GetTeamDefenseRankings(c *gin.Context) {
+	sortField := c.DefaultQuery("sort", "epa_per_play_allowed_rank")
+	desc := strings.EqualFold(c.Query("order"), "desc")
+
+	teams, err := database.GetNFLTeamDefenseRankings(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team defense rankings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := sortByJSONField(teams, sortField, desc); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid sort parameter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sort":  sortField,
+		"count": len(teams),
+		"teams": teams,
+	})
+}
+
+// GetTeamOffenseRankings lists offensive stats for every team, sortable by any metric
+// via ?sort=<field>&order=asc|desc
+func (h *PlayerHandler) GetTeamOffenseRankings(c *gin.Context) {
+	sortField := c.DefaultQuery("sort", "epa_per_play_rank")
+	desc := strings.EqualFold(c.Query("order"), "desc")
+
+	teams, err := database.GetNFLTeamOffenseRankings(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team offense rankings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := sortByJSONField(teams, sortField, desc); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid sort parameter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sort":  sortField,
+		"count": len(teams),
+		"teams": teams,
+	})
+}
+
+// GetTeamDefenseStatsV2 returns a single team's defensive stats using the typed v2 model
+func (h *PlayerHandler) GetTeamDefenseStatsV2(c *gin.Context) {
+	teamName := c.Param("team")
+
+	teams, err := database.GetNFLTeamDefenseRankings(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team defense stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	for _, team := range teams {
+		if strings.EqualFold(team.TeamName, teamName) {
+			c.JSON(http.StatusOK, gin.H{
+				"stats": team,
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{
+		"error": "No defense stats found for team: " + teamName,
+	})
+}
+
+// GetTeamOffenseStatsV2 returns a single team's offensive stats using the typed v2 model
+func (h *PlayerHandler) GetTeamOffenseStatsV2(c *gin.Context) {
+	teamName := c.Param("team")
+
+	teams, err := database.GetNFLTeamOffenseRankings(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team offense stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	for _, team := range teams {
+		if strings.EqualFold(team.TeamName, teamName) {
+			c.JSON(http.StatusOK, gin.H{
+				"stats": team,
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{
+		"error": "No offense stats found for team: " + teamName,
+	})
+}
+
+// GetDefenseVsPosition returns per-game allowances to QB/RB/WR/TE for every defense in a season.
+// Optional query params: position, team, sort, order
+func (h *PlayerHandler) GetDefenseVsPosition(c *gin.Context) {
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid season parameter",
+			"details": err.Error(),
+		})
+		return
+	}
+	position := strings.ToUpper(strings.TrimSpace(c.Query("position")))
+	teamName := strings.TrimSpace(c.Query("team"))
+	if teamName != "" {
+		teamName = nflTeamParam(teamName).TeamName
+	}
+
+	stats, err := database.GetNFLDefenseVsPosition(h.db, season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve defense vs position stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Filter after the query so ranks stay league-wide
+	filtered := stats[:0]
+	for _, s := range stats {
+		if position != "" && s.Position != position {
+			continue
+		}
+		if teamName != "" && !strings.EqualFold(s.TeamName, teamName) {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+
+	if sortField := c.Query("sort"); sortField != "" {
+		if err := sortByJSONField(filtered, sortField, strings.EqualFold(c.Query("order"), "desc")); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid sort parameter",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season": season,
+		"count":  len(filtered),
+		"stats":  filtered,
+	})
+}
+
+// GetNFLPassingGrid aggregates a QB's pass attempts into a location x length grid, overall and per week
+func (h *PlayerHandler) GetNFLPassingGrid(c *gin.Context) {
+	playerName := c.Param("player")
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid season parameter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	plays, err := database.GetNFLPassingPBPStats(h.db, c.Param("player_id"), season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve passing PBP stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player":  playerName,
+		"season":  season,
+		"overall": buildPassingGrid(plays),
+		"weeks":   buildWeeklyPassingGrids(plays),
+	})
+}
+
+// GetNFLDefensePassingGrid aggregates pass attempts a defense allowed into a location x length grid,
+// overall and per week
+func (h *PlayerHandler) GetNFLDefensePassingGrid(c *gin.Context) {
+	// team IDs are the abbreviations play-by-play data identifies teams by
+	teamID := c.Param("team_id")
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid season parameter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	plays, err := database.GetNFLDefensePassingPBPStats(h.db, teamID, season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve defense passing PBP stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team":    teamID,
+		"season":  season,
+		"overall": buildPassingGrid(plays),
+		"weeks":   buildWeeklyPassingGrids(plays),
+	})
+}
+
+// seasonQuery reads the optional ?season query param, defaulting to the latest season in the data.
+// On failure it has already written the error response.
+func (h *PlayerHandler) seasonQuery(c *gin.Context) (int, bool) {
+	return seasonParam(c, parseNFLSeason, func() (int, error) {
+		return database.GetNFLLatestSeason(h.db)
+	})
+}
+
+// parseNFLSeason parses a season year such as 2024
+func parseNFLSeason(s string) (int, error) {
+	season, err := strconv.Atoi(s)
+	if err != nil || season <= 0 {
+		return 0, fmt.Errorf("invalid season: %s", s)
+	}
+	return season, nil
+}
+
+// GetPlayerUsage returns a pass catcher's weekly and season target share, air yards share,
+// WOPR, aDOT and yards per route proxy
+func (h *PlayerHandler) GetPlayerUsage(c *gin.Context) {
+	playerName := c.Param("player")
+	if strings.TrimSpace(playerName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Player name is required",
+		})
+		return
+	}
+
+	season, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	weeks, err := database.GetNFLReceiverUsage(h.db, season, c.Param("player_id"), "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve player usage",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(weeks) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No usage found for player: " + playerName,
+		})
+		return
+	}
+
+	for i := range weeks {
+		computeUsageRates(&weeks[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player": playerName,
+		"season": season,
+		"totals": summarizeUsage(weeks)[0],
+		"weeks":  weeks,
+	})
+}
+
+// GetTeamUsageLeaderboard ranks a team's pass catchers by season WOPR
+func (h *PlayerHandler) GetTeamUsageLeaderboard(c *gin.Context) {
+	teamName := c.Param("team")
+	if strings.TrimSpace(teamName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Team name is required",
+		})
+		return
+	}
+
+	season, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	weeks, err := database.GetNFLReceiverUsage(h.db, season, "", c.Param("team_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team usage",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Team totals are the same for every player in a week, so take them once per week
+	var teamTargets int
+	var teamAirYards float64
+	seenWeeks := make(map[int]bool)
+	for _, w := range weeks {
+		if seenWeeks[w.Week] {
+			continue
+		}
+		seenWeeks[w.Week] = true
+		teamTargets += w.TeamTargets
+		teamAirYards += w.TeamAirYards
+	}
+
+	players := summarizeUsage(weeks)
+	c.JSON(http.StatusOK, gin.H{
+		"team":           teamName,
+		"season":         season,
+		"games":          len(seenWeeks),
+		"team_targets":   teamTargets,
+		"team_air_yards": teamAirYards,
+		"count":          len(players),
+		"players":        players,
+	})
+}
+
+// GetPlayerSnapTrend returns a player's weekly offensive snaps and snap % with changes and rolling averages
+func (h *PlayerHandler) GetPlayerSnapTrend(c *gin.Context) {
+	playerName := c.Param("player")
+	if strings.TrimSpace(playerName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Player name is required",
+		})
+		return
+	}
+
+	season, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	weeks, err := database.GetNFLSnapCounts(h.db, season, c.Param("player_id"), "", 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve player snap counts",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(weeks) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No snap counts found for player: " + playerName,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player": playerName,
+		"season": season,
+		"weeks":  weeks,
+	})
+}
+
+// GetTeamSnapTrends returns weekly snap trends for every player on a team
+func (h *PlayerHandler) GetTeamSnapTrends(c *gin.Context) {
+	teamName := c.Param("team")
+	if strings.TrimSpace(teamName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Team name is required",
+		})
+		return
+	}
+
+	season, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	weeks, err := database.GetNFLSnapCounts(h.db, season, "", c.Param("team_id"), 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team snap counts",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Rows come back ordered by player then week
+	var players []models.NFLPlayerSnapTrend
+	for _, w := range weeks {
+		if len(players) == 0 || players[len(players)-1].PlayerName != w.PlayerName {
+			players = append(players, models.NFLPlayerSnapTrend{PlayerName: w.PlayerName, Position: w.Position})
+		}
+		last := &players[len(players)-1]
+		last.Weeks = append(last.Weeks, w)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team":    teamName,
+		"season":  season,
+		"count":   len(players),
+		"players": players,
+	})
+}
+
+// GetSnapShareMovers lists the biggest snap % risers and fallers for a week versus each player's
+// previous game. Optional query params: season, team, limit (default 10)
+func (h *PlayerHandler) GetSnapShareMovers(c *gin.Context) {
+	week, err := strconv.Atoi(c.Param("week"))
+	if err != nil || week <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid week parameter",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	season, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	var teamID string
+	if team := c.Query("team"); team != "" {
+		teamID = nflTeamParam(team).TeamID
+	}
+	weeks, err := database.GetNFLSnapCounts(h.db, season, "", teamID, week)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve snap counts",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	risers, fallers := snapShareMovers(weeks, limit)
+	c.JSON(http.StatusOK, gin.H{
+		"season":  season,
+		"week":    week,
+		"risers":  risers,
+		"fallers": fallers,
+	})
+}
+
+// snapShareMovers ranks the weeks by snap % change versus the player's previous game, returning up
+// to limit risers (largest gain first) and fallers (largest drop first). A player's first game and
+// unchanged snap shares are neither.
+func snapShareMovers(weeks []models.NFLSnapCountWeek, limit int) ([]models.NFLSnapCountWeek, []models.NFLSnapCountWeek) {
+	var changed []models.NFLSnapCountWeek
+	for _, w := range weeks {
+		if w.PriorWeek > 0 {
+			changed = append(changed, w)
+		}
+	}
+
+	sort.SliceStable(changed, func(i, j int) bool { return changed[i].SnapPctChange > changed[j].SnapPctChange })
+	var risers, fallers []models.NFLSnapCountWeek
+	for i := 0; i < len(changed) && i < limit && changed[i].SnapPctChange > 0; i++ {
+		risers = append(risers, changed[i])
+	}
+	for i := len(changed) - 1; i >= 0 && len(fallers) < limit && changed[i].SnapPctChange < 0; i-- {
+		fallers = append(fallers, changed[i])
+	}
+	return risers, fallers
+}
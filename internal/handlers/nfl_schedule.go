@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"sports_api/internal/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSchedule lists NFL games with kickoff times, final scores and the prop markets offered.
+// Optional ?season, ?week, ?week_from, ?week_to and ?team (any form the team registry accepts)
+// narrow the list.
+func (h *PlayerHandler) GetSchedule(c *gin.Context) {
+	filter, err := eventFilterQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid schedule filter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	events, err := database.GetEvents(h.db, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve schedule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":  len(events),
+		"events": events,
+	})
+}
+
+// GetWeekScoreboard returns every game in a week of ?season (default: latest season) with
+// status and scores
+func (h *PlayerHandler) GetWeekScoreboard(c *gin.Context) {
+	week, err := strconv.Atoi(c.Param("week"))
+	if err != nil || week <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid week: " + c.Param("week"),
+		})
+		return
+	}
+
+	season, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	events, err := database.GetEvents(h.db, database.NFLEventFilter{Season: season, WeekFrom: week, WeekTo: week})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve scoreboard",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season": season,
+		"week":   week,
+		"count":  len(events),
+		"games":  events,
+	})
+}
+
+// eventFilterQuery parses the schedule's query params. ?week is shorthand for the same
+// week_from and week_to.
+func eventFilterQuery(c *gin.Context) (database.NFLEventFilter, error) {
+	var filter database.NFLEventFilter
+	for param, dests := range map[string][]*int{
+		"season":    {&filter.Season},
+		"week":      {&filter.WeekFrom, &filter.WeekTo},
+		"week_from": {&filter.WeekFrom},
+		"week_to":   {&filter.WeekTo},
+	} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return filter, fmt.Errorf("invalid %s: %s", param, v)
+			}
+			for _, dest := range dests {
+				*dest = n
+			}
+		}
+	}
+	if c.Query("week") != "" && (c.Query("week_from") != "" || c.Query("week_to") != "") {
+		return filter, fmt.Errorf("week can't be combined with week_from or week_to")
+	}
+	if filter.WeekFrom > 0 && filter.WeekTo > 0 && filter.WeekFrom > filter.WeekTo {
+		return filter, fmt.Errorf("week_from %d is after week_to %d", filter.WeekFrom, filter.WeekTo)
+	}
+
+	if team := strings.TrimSpace(c.Query("team")); team != "" {
+		filter.Team = nflTeamParam(team).TeamName
+	}
+	return filter, nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"sports_api/internal/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilterQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    database.NFLEventFilter
+		wantErr bool
+	}{
+		{name: "no filters", query: ""},
+		{
+			name:  "single week and team",
+			query: "?season=2024&week=5&team=KC",
+			want:  database.NFLEventFilter{Season: 2024, WeekFrom: 5, WeekTo: 5, Team: "Kansas City Chiefs"},
+		},
+		{
+			name:  "week range",
+			query: "?week_from=3&week_to=8",
+			want:  database.NFLEventFilter{WeekFrom: 3, WeekTo: 8},
+		},
+		{name: "week with range", query: "?week=3&week_to=8", wantErr: true},
+		{name: "bad week", query: "?week=abc", wantErr: true},
+		{name: "reversed weeks", query: "?week_from=9&week_to=2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/schedule"+tt.query, nil)
+
+			got, err := eventFilterQuery(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"sort"
+	"strings"
+
+	"sports_api/internal/models"
+)
+
+var (
+	passLocations = []string{"left", "middle", "right"}
+	passLengths   = []string{"short", "deep"}
+)
+
+// buildPassingGrid aggregates pass plays into the location x length grid. Every cell is
+// present, in a fixed order, even when it has no attempts; plays outside the grid are skipped.
+func buildPassingGrid(plays []models.NFLPassingPBPStats) models.NFLPassingGrid {
+	cells := make([]models.NFLPassingGridCell, 0, len(passLocations)*len(passLengths))
+	index := make(map[string]int)
+	airYards := make([]int, cap(cells))
+	for _, location := range passLocations {
+		for _, length := range passLengths {
+			index[location+"/"+length] = len(cells)
+			cells = append(cells, models.NFLPassingGridCell{PassLocation: location, PassLength: length})
+		}
+	}
+
+	grid := models.NFLPassingGrid{}
+	for _, play := range plays {
+		i, ok := index[strings.ToLower(play.PassLocation)+"/"+strings.ToLower(play.PassLength)]
+		if !ok {
+			continue
+		}
+		cells[i].Attempts++
+		cells[i].Completions += play.CompletePass
+		cells[i].Interceptions += play.Interception
+		airYards[i] += play.AirYards
+		grid.Attempts++
+	}
+
+	for i := range cells {
+		if cells[i].Attempts == 0 {
+			continue
+		}
+		attempts := float64(cells[i].Attempts)
+		cells[i].CompletionPct = float64(cells[i].Completions) / attempts * 100
+		cells[i].InterceptionRate = float64(cells[i].Interceptions) / attempts * 100
+		cells[i].AvgAirYards = float64(airYards[i]) / attempts
+	}
+	grid.Cells = cells
+	return grid
+}
+
+// buildWeeklyPassingGrids builds one grid per week, ordered by week
+func buildWeeklyPassingGrids(plays []models.NFLPassingPBPStats) []models.NFLPassingGrid {
+	byWeek := make(map[int][]models.NFLPassingPBPStats)
+	for _, play := range plays {
+		byWeek[play.Week] = append(byWeek[play.Week], play)
+	}
+
+	weeks := make([]models.NFLPassingGrid, 0, len(byWeek))
+	for week, weekPlays := range byWeek {
+		grid := buildPassingGrid(weekPlays)
+		grid.Week = week
+		weeks = append(weeks, grid)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].Week < weeks[j].Week })
+	return weeks
+}
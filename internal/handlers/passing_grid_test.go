@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPassingGrid(t *testing.T) {
+	plays := []models.NFLPassingPBPStats{
+		{Week: 1, CompletePass: 1, AirYards: 4, PassLocation: "left", PassLength: "short"},
+		{Week: 1, CompletePass: 0, AirYards: 8, PassLocation: "left", PassLength: "short"},
+		{Week: 2, Interception: 1, AirYards: 30, PassLocation: "right", PassLength: "deep"},
+		{Week: 2, PassLocation: "", PassLength: ""},
+	}
+
+	grid := buildPassingGrid(plays)
+	assert.Equal(t, 3, grid.Attempts)
+	assert.Len(t, grid.Cells, 6)
+
+	leftShort := grid.Cells[0]
+	assert.Equal(t, "left", leftShort.PassLocation)
+	assert.Equal(t, "short", leftShort.PassLength)
+	assert.Equal(t, 2, leftShort.Attempts)
+	assert.InDelta(t, 50.0, leftShort.CompletionPct, 1e-9)
+	assert.InDelta(t, 6.0, leftShort.AvgAirYards, 1e-9)
+
+	rightDeep := grid.Cells[5]
+	assert.Equal(t, 1, rightDeep.Attempts)
+	assert.InDelta(t, 100.0, rightDeep.InterceptionRate, 1e-9)
+
+	weeks := buildWeeklyPassingGrids(plays)
+	assert.Len(t, weeks, 2)
+	assert.Equal(t, 1, weeks[0].Week)
+	assert.Equal(t, 2, weeks[0].Attempts)
+	assert.Equal(t, 2, weeks[1].Week)
+	assert.Equal(t, 1, weeks[1].Attempts)
+}
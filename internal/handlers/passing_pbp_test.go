@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/marcboeker/go-duckdb/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openFixture opens an in-memory database holding the given statements
+func openFixture(t *testing.T, statements ...string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("duckdb", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	for _, stmt := range statements {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+	return db
+}
+
+func TestPassingPBPStatsRedirectsPasserNames(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, player_name VARCHAR, "position" VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Patrick Mahomes', 'QB', 'Kansas City Chiefs', 'KC'), ('2', 'Pat Mahomes', 'WR', 'Buffalo Bills', 'BUF')`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, team VARCHAR)`,
+		`CREATE TABLE nfl_data.nfl_pbp_qb_data (season INT, week INT, posteam VARCHAR, passer VARCHAR, opponent VARCHAR, complete_pass INT, interception INT, air_yards INT, pass_location VARCHAR, pass_length VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_pbp_qb_data VALUES
+			(2024, 1, 'KC', 'P.Mahomes', 'BAL', 1, 0, 8, 'left', 'short'),
+			(2024, 1, 'KC', 'P.Mahomes', 'BAL', 0, 0, 22, 'right', 'deep'),
+			(2024, 1, 'BUF', 'P.Mahomes', 'ARI', 1, 0, 3, 'middle', 'short'),
+			(2023, 1, 'KC', 'P.Mahomes', 'DET', 1, 0, 5, 'left', 'short'),
+			(2024, 1, 'KC', 'P.Mahomes', 'BAL', 0, 0, NULL, NULL, NULL)`,
+	)
+	h := &PlayerHandler{db: db}
+
+	router := gin.New()
+	router.GET("/players/:player/passing-pbp-stats/:season", h.RedirectPasserName("player", "/players/id/:player_id/passing-pbp-stats/:season"))
+	router.GET("/players/id/:player_id/passing-pbp-stats/:season", h.PlayerByID("player", h.GetNFLPassingPBPStats))
+
+	for _, name := range []string{"P.Mahomes", "Patrick%20Mahomes"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/players/"+name+"/passing-pbp-stats/2024", nil))
+		require.Equal(t, http.StatusTemporaryRedirect, w.Code, name)
+		location := w.Header().Get("Location")
+		assert.Equal(t, "/players/id/1/passing-pbp-stats/2024", location, name)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, location, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Player string                      `json:"player"`
+			Stats  []models.NFLPassingPBPStats `json:"stats"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "Patrick Mahomes", body.Player)
+		// the Bills' "P.Mahomes" and last season's play are someone else's, and the sack has no target
+		require.Len(t, body.Stats, 2, name)
+		assert.Equal(t, "BAL", body.Stats[0].Opponent)
+	}
+}
+
+func TestPassingGridSkipsUntargetedPlays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openFixture(t,
+		`CREATE SCHEMA nfl_data`,
+		`CREATE TABLE nfl_data.nfl_roster_db (player_id VARCHAR, player_name VARCHAR, "position" VARCHAR, team_name VARCHAR, team_id VARCHAR)`,
+		`INSERT INTO nfl_data.nfl_roster_db VALUES ('1', 'Patrick Mahomes', 'QB', 'Kansas City Chiefs', 'KC')`,
+		`CREATE TABLE nfl_data.nfl_player_snap_counts (player_id VARCHAR, season INT, game_week INT, team VARCHAR)`,
+		`CREATE TABLE nfl_data.nfl_pbp_qb_data (season INT, week INT, posteam VARCHAR, passer VARCHAR, opponent VARCHAR, complete_pass INT, interception INT, air_yards INT, pass_location VARCHAR, pass_length VARCHAR)`,
+		// a sack and a spike alongside one real attempt
+		`INSERT INTO nfl_data.nfl_pbp_qb_data VALUES
+			(2024, 1, 'KC', 'P.Mahomes', 'BAL', 1, 0, 8, 'left', 'short'),
+			(2024, 1, 'KC', 'P.Mahomes', 'BAL', 0, 0, NULL, NULL, NULL),
+			(2024, 1, 'KC', 'P.Mahomes', 'BAL', 0, 0, -1, NULL, 'short')`,
+	)
+	h := &PlayerHandler{db: db}
+
+	router := gin.New()
+	router.GET("/players/id/:player_id/passing-grid/:season", h.PlayerByID("player", h.GetNFLPassingGrid))
+	router.GET("/teams/:team_id/passing-grid-allowed/:season", h.TeamByID("team", h.GetNFLDefensePassingGrid))
+
+	for _, path := range []string{"/players/id/1/passing-grid/2024", "/teams/BAL/passing-grid-allowed/2024"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body struct {
+			Overall models.NFLPassingGrid `json:"overall"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, 1, body.Overall.Attempts, path)
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDKey is the gin context key authentication middleware sets to the authenticated user's ID.
+// Per-user handlers act only for that user, never for an ID taken from the path or query.
+const UserIDKey = "auth_user_id"
+
+// authenticatedUser returns the authenticated user's ID, responding 401 when the request has none
+func authenticatedUser(c *gin.Context) (string, bool) {
+	userID := c.GetString(UserIDKey)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// Authenticate is middleware that authenticates callers by an HS256-signed JWT in the
+// Authorization: Bearer header and sets the token's subject under UserIDKey. Tokens must carry sub
+// and exp claims. With no secret configured every request is refused.
+func Authenticate(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Authentication required",
+			})
+			return
+		}
+
+		userID, err := verifyToken(secret, strings.TrimSpace(token), time.Now())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Invalid token",
+				"details": err.Error(),
+			})
+			return
+		}
+		c.Set(UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// verifyToken checks an HS256 JWT's signature and expiry and returns its subject
+func verifyToken(secret []byte, token string, now time.Time) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("authentication is not configured")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeTokenPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return "", errors.New("token must be signed with HS256")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.New("malformed token signature")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", errors.New("bad token signature")
+	}
+
+	var claims struct {
+		Sub string `json:"sub"`
+		Exp int64  `json:"exp"`
+	}
+	if err := decodeTokenPart(parts[1], &claims); err != nil {
+		return "", errors.New("malformed token claims")
+	}
+	if claims.Sub == "" {
+		return "", errors.New("token has no subject")
+	}
+	if claims.Exp == 0 || !now.Before(time.Unix(claims.Exp, 0)) {
+		return "", errors.New("token has expired")
+	}
+	return claims.Sub, nil
+}
+
+func decodeTokenPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
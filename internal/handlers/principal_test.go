@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerUserRoutesRequireAuthenticatedUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	alerts, bets := &AlertHandler{}, &BetHandler{}
+
+	for name, handler := range map[string]gin.HandlerFunc{
+		"create alert":     alerts.CreateAlertRule,
+		"list alerts":      alerts.GetAlertRules,
+		"delete alert":     alerts.DeleteAlertRule,
+		"alert deliveries": alerts.GetAlertDeliveries,
+		"list bets":        bets.GetBets,
+		"bet summary":      bets.GetBetSummary,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			// a user ID in the path isn't a principal
+			c.Params = gin.Params{{Key: "user_id", Value: "someone-else"}}
+
+			handler(c)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+// signToken builds an HS256 JWT from raw header and claims JSON
+func signToken(secret, header, claims string) string {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const hs256 = `{"alg":"HS256","typ":"JWT"}`
+	future := time.Now().Add(time.Hour).Unix()
+	valid := signToken("secret", hs256, `{"sub":"user-1","exp":`+strconv.FormatInt(future, 10)+`}`)
+
+	tests := []struct {
+		name     string
+		secret   string
+		header   string
+		wantCode int
+	}{
+		{name: "valid token", secret: "secret", header: "Bearer " + valid, wantCode: http.StatusOK},
+		{name: "no token", secret: "secret", wantCode: http.StatusUnauthorized},
+		{name: "not a bearer token", secret: "secret", header: valid, wantCode: http.StatusUnauthorized},
+		{name: "wrong secret", secret: "other", header: "Bearer " + valid, wantCode: http.StatusUnauthorized},
+		{name: "no secret configured", header: "Bearer " + signToken("", hs256, `{"sub":"user-1","exp":`+strconv.FormatInt(future, 10)+`}`), wantCode: http.StatusUnauthorized},
+		{name: "expired", secret: "secret", header: "Bearer " + signToken("secret", hs256, `{"sub":"user-1","exp":1}`), wantCode: http.StatusUnauthorized},
+		{name: "no expiry", secret: "secret", header: "Bearer " + signToken("secret", hs256, `{"sub":"user-1"}`), wantCode: http.StatusUnauthorized},
+		{name: "no subject", secret: "secret", header: "Bearer " + signToken("secret", hs256, `{"exp":`+strconv.FormatInt(future, 10)+`}`), wantCode: http.StatusUnauthorized},
+		{name: "unsigned", secret: "secret", header: "Bearer " + signToken("secret", `{"alg":"none"}`, `{"sub":"user-1","exp":`+strconv.FormatInt(future, 10)+`}`), wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/me", Authenticate([]byte(tt.secret)), func(c *gin.Context) {
+				userID, ok := authenticatedUser(c)
+				if ok {
+					c.String(http.StatusOK, userID)
+				}
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				assert.Equal(t, "user-1", w.Body.String())
+			}
+		})
+	}
+}
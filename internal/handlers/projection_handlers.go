@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+	"sports_api/internal/projection"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetModels lists the registered projection models and what each one uses
+func GetModels(c *gin.Context) {
+	list := projection.Models()
+	c.JSON(http.StatusOK, gin.H{
+		"default": projection.DefaultModel,
+		"count":   len(list),
+		"models":  list,
+	})
+}
+
+// GetDistribution calculates under, push and over probabilities for an NBA line under the
+// requested distribution: poisson (default), negative_binomial, normal or empirical
+func (h *NBAHandler) GetDistribution(c *gin.Context) {
+	distribution(c, h.db, "nba")
+}
+
+// GetDistribution calculates under, push and over probabilities for an NFL line under the
+// requested distribution, estimating spread from the gamelog of the player named by player_id
+func (h *PlayerHandler) GetDistribution(c *gin.Context) {
+	distribution(c, h.db, "nfl")
+}
+
+// distribution serves GetDistribution for a sport. Markets default to player_points for NBA and
+// are required for NFL.
+func distribution(c *gin.Context, db *sql.DB, sport string) {
+	var dist models.PoissonDist
+	if err := c.ShouldBindJSON(&dist); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	if err := projection.ValidateLine(max(dist.PredictedPoints, 0), dist.BookLine); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid line",
+			"details": err.Error(),
+		})
+		return
+	}
+	if dist.Distribution == "" {
+		dist.Distribution = projection.DefaultDistribution
+	}
+	if !slices.Contains(projection.Distributions(), dist.Distribution) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid distribution: " + dist.Distribution,
+			"valid": projection.Distributions(),
+		})
+		return
+	}
+	if dist.Market == "" && sport == "nba" {
+		dist.Market = "player_points"
+	}
+	if !slices.Contains(database.BetMarkets(sport), dist.Market) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid market: " + dist.Market,
+			"valid": database.BetMarkets(sport),
+		})
+		return
+	}
+
+	var sample []float64
+	if dist.PlayerID != "" {
+		var err error
+		if sample, err = distributionSample(db, sport, dist.Market, dist.PlayerID, time.Now()); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve player games",
+				"details": err.Error(),
+			})
+			return
+		}
+		if dist.Games > 0 && len(sample) > dist.Games {
+			sample = sample[len(sample)-dist.Games:]
+		}
+	}
+
+	mean := dist.PredictedPoints
+	if mean <= 0 {
+		mean, _ = projection.MeanVariance(sample)
+	}
+	over, under, push, err := projection.OverUnder(dist.Distribution, mean, sample, dist.BookLine)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Not enough data for distribution",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PoissonResponse{
+		Distribution: dist.Distribution,
+		Mean:         mean,
+		Line:         dist.BookLine,
+		Games:        len(sample),
+		Under:        under,
+		Push:         push,
+		Over:         over,
+		Less:         under,
+		Greater:      over,
+	})
+}
+
+// distributionSample is a player's value in a market in each game over the year before now,
+// oldest first. NBA players are given by ID, NFL players by gamelog name.
+func distributionSample(db *sql.DB, sport, market, player string, now time.Time) ([]float64, error) {
+	from := now.AddDate(-1, 0, 0).Format(time.DateOnly)
+	var sample []float64
+	if sport == "nba" {
+		stats, err := database.GetNBAMarketGameStats(db, market, player, from, now.Format(time.DateOnly))
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stats {
+			sample = append(sample, s.Value)
+		}
+		return sample, nil
+	}
+
+	components, _ := database.MarketComponents(sport, market)
+	games, err := database.GetSimulationGames(db, sport, player, components, from)
+	if err != nil {
+		return nil, err
+	}
+	for _, game := range games {
+		value := 0.0
+		for _, column := range components {
+			value += game.Stats[column]
+		}
+		sample = append(sample, value)
+	}
+	return sample, nil
+}
+
+// projectorsQuery resolves the comma-separated ?model param to registered projectors, defaulting
+// to the default model
+func projectorsQuery(c *gin.Context) ([]projection.Projector, error) {
+	names := listQuery(c, "model")
+	if len(names) == 0 {
+		names = []string{projection.DefaultModel}
+	}
+
+	projectors := make([]projection.Projector, 0, len(names))
+	for _, name := range names {
+		p, ok := projection.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown model %q, valid models: %s", name, strings.Join(modelNames(), ", "))
+		}
+		projectors = append(projectors, p)
+	}
+	return projectors, nil
+}
+
+func modelNames() []string {
+	names := []string{}
+	for _, m := range projection.Models() {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+// opponentFactorsMaxAge is how long PointsPrediction reuses the league's opponent factors
+const opponentFactorsMaxAge = time.Hour
+
+// opponentFactors returns how much each opponent allows per player-game relative to the league over
+// the past year, keyed by team ID. The factors come from the whole league's box scores, so they are
+// computed once and cached for opponentFactorsMaxAge rather than on every request.
+func (h *NBAHandler) opponentFactors() (map[string]float64, error) {
+	h.factorsMu.Lock()
+	defer h.factorsMu.Unlock()
+	if h.factors != nil && time.Since(h.factorsAt) < opponentFactorsMaxAge {
+		return h.factors, nil
+	}
+
+	to := time.Now()
+	from := to.AddDate(-1, 0, 0)
+	league, err := database.GetNBAMarketGameStats(h.db, "player_points", "", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	h.factors, h.factorsAt = leagueOpponentFactors(league), to
+	return h.factors, nil
+}
+
+// leagueOpponentFactors is how much each opponent allows per player-game relative to the league
+// across the given games, keyed by team ID. Opponents with too few games to say are left out.
+func leagueOpponentFactors(league []models.NBAPlayerGameStat) map[string]float64 {
+	games := make([]projection.Game, 0, len(league))
+	teams := map[string]bool{}
+	for _, s := range league {
+		id := nbaTeamParam(s.Opponent).TeamID
+		games = append(games, projection.Game{Date: s.GameDate, Opponent: id, Value: s.Value})
+		teams[id] = true
+	}
+	// an entry per team after every real game picks up the factor from all of them
+	next := len(games)
+	for id := range teams {
+		games = append(games, projection.Game{Date: "9999-12-31", Opponent: id})
+	}
+
+	factors := map[string]float64{}
+	for i, factor := range projection.OpponentFactors(games, projection.OpponentMinGames)[next:] {
+		if factor != 1 {
+			factors[games[next+i].Opponent] = factor
+		}
+	}
+	return factors
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sports_api/internal/models"
+	"sports_api/internal/projection"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetModels(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/models", nil)
+
+	GetModels(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Default string                   `json:"default"`
+		Models  []models.ProjectionModel `json:"models"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, projection.DefaultModel, body.Default)
+	assert.Len(t, body.Models, len(projection.Models()))
+}
+
+func TestProjectorsQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{query: "", want: []string{projection.DefaultModel}},
+		{query: "?model=weighted_recent", want: []string{"weighted_recent"}},
+		{query: "?model=rolling_average,+per_minute", want: []string{"rolling_average", "per_minute"}},
+		{query: "?model=rolling_average,nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			projectors, err := projectorsQuery(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			names := []string{}
+			for _, p := range projectors {
+				names = append(names, p.Info().Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestLeagueOpponentFactors(t *testing.T) {
+	var league []models.NBAPlayerGameStat
+	for i := 0; i < projection.OpponentMinGames; i++ {
+		date := fmt.Sprintf("2024-01-%02d", i%28+1)
+		league = append(league,
+			models.NBAPlayerGameStat{GameDate: date, Opponent: "BOS", Value: 30},
+			models.NBAPlayerGameStat{GameDate: date, Opponent: "NYK", Value: 10},
+		)
+	}
+
+	factors := leagueOpponentFactors(league)
+	assert.InDelta(t, 1.5, factors[nbaTeamParam("Boston").TeamID], 1e-9)
+	assert.InDelta(t, 0.5, factors[nbaTeamParam("Knicks").TeamID], 1e-9)
+	assert.NotContains(t, factors, nbaTeamParam("Miami").TeamID)
+	assert.Empty(t, leagueOpponentFactors(league[:2]))
+}
+
+func TestGetDistribution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &NBAHandler{}
+
+	tests := []struct {
+		name     string
+		nfl      bool
+		body     string
+		wantCode int
+	}{
+		{name: "poisson by default", body: `{"predictedPoints": 2, "bookLine": 1.5}`, wantCode: http.StatusOK},
+		{name: "nfl market", nfl: true, body: `{"predictedPoints": 2, "bookLine": 1.5, "market": "player_pass_tds"}`, wantCode: http.StatusOK},
+		{name: "nfl without a market", nfl: true, body: `{"predictedPoints": 2, "bookLine": 1.5}`, wantCode: http.StatusBadRequest},
+		{name: "nba market on nfl", nfl: true, body: `{"predictedPoints": 2, "bookLine": 1.5, "market": "player_points"}`, wantCode: http.StatusBadRequest},
+		{name: "nfl market on nba", body: `{"predictedPoints": 2, "bookLine": 1.5, "market": "player_pass_tds"}`, wantCode: http.StatusBadRequest},
+		{name: "unknown distribution", body: `{"predictedPoints": 2, "bookLine": 1.5, "distribution": "cauchy"}`, wantCode: http.StatusBadRequest},
+		{name: "unknown market", body: `{"predictedPoints": 2, "bookLine": 1.5, "market": "player_steals"}`, wantCode: http.StatusBadRequest},
+		{name: "normal without a game log", body: `{"predictedPoints": 2, "bookLine": 1.5, "distribution": "normal"}`, wantCode: http.StatusBadRequest},
+		{name: "huge line", body: `{"predictedPoints": 2, "bookLine": 1e15}`, wantCode: http.StatusBadRequest},
+		{name: "huge mean", body: `{"predictedPoints": 1e15, "bookLine": 1.5}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/distribution", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			if tt.nfl {
+				(&PlayerHandler{}).GetDistribution(c)
+			} else {
+				h.GetDistribution(c)
+			}
+
+			require.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var resp models.PoissonResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "poisson", resp.Distribution)
+			assert.InDelta(t, 0.5940, resp.Over, 1e-4)
+			assert.InDelta(t, 0.4060, resp.Under, 1e-4)
+			assert.Equal(t, resp.Over, resp.Greater)
+			assert.Equal(t, resp.Under, resp.Less)
+		})
+	}
+}
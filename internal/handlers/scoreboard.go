@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetScoreboard returns the games on ?date (YYYY-MM-DD, defaulting to the latest scoreboard date)
+// with status, period, clock, scores and each side's last moneyline before tip-off. Start times are given in
+// ?tz (an IANA zone such as America/Los_Angeles), defaulting to US Eastern.
+func (h *NBAHandler) GetScoreboard(c *gin.Context) {
+	date := strings.TrimSpace(c.Query("date"))
+	if date != "" {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid date, expected YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", "America/New_York"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid time zone",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	scoreboard, err := database.GetScoreboard(h.db, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve scoreboard",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	odds, err := database.GetScoreboardMoneylineOdds(h.db, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve odds",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildScoreboard(scoreboard, odds, loc))
+}
+
+// buildScoreboard fills in each game's status, clock and start time in loc and attaches the
+// moneyline odds for both teams from that game's odds (keyed by game ID), matching odds to teams
+// in any form the team registry accepts
+func buildScoreboard(games []models.Game, odds map[string][]models.MoneylineOdds, loc *time.Location) []models.Game {
+	for i := range games {
+		game := &games[i]
+		game.Status = gameStatus(game.StatusCode)
+		game.GameClock = formatGameClock(game.GameClock)
+		if game.StartTime != nil {
+			start := game.StartTime.In(loc)
+			game.StartTime = &start
+		}
+
+		oddsByTeam := make(map[string][]models.MoneylineOdds)
+		for _, odd := range odds[game.GameID] {
+			id := nbaTeamParam(odd.Team).TeamID
+			oddsByTeam[id] = append(oddsByTeam[id], odd)
+		}
+		game.HomeOdds = teamOdds(oddsByTeam, game.HomeCity, game.HomeTeam)
+		game.AwayOdds = teamOdds(oddsByTeam, game.AwayCity, game.AwayTeam)
+	}
+	return games
+}
+
+// teamOdds looks up a scoreboard team's odds by full name, falling back to the nickname alone
+func teamOdds(oddsByTeam map[string][]models.MoneylineOdds, city, name string) []models.MoneylineOdds {
+	odds := oddsByTeam[nbaTeamParam(city+" "+name).TeamID]
+	if odds == nil {
+		odds = oddsByTeam[nbaTeamParam(name).TeamID]
+	}
+	if odds == nil {
+		return []models.MoneylineOdds{}
+	}
+	return odds
+}
+
+// gameStatus maps the NBA feed's numeric game status to scheduled, live or final
+func gameStatus(code int) string {
+	switch code {
+	case 1:
+		return "scheduled"
+	case 2:
+		return "live"
+	case 3:
+		return "final"
+	default:
+		return "unknown"
+	}
+}
+
+// formatGameClock turns the feed's ISO 8601 clock ("PT05M32.00S") into "5:32". Clocks in any
+// other form are returned unchanged.
+func formatGameClock(clock string) string {
+	rest, ok := strings.CutPrefix(clock, "PT")
+	if !ok {
+		return clock
+	}
+	var minutes int
+	var seconds float64
+	if _, err := fmt.Sscanf(rest, "%dM%fS", &minutes, &seconds); err != nil {
+		return clock
+	}
+	return fmt.Sprintf("%d:%02d", minutes, int(seconds))
+}
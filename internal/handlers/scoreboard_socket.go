@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"sports_api/internal/models"
+	"sports_api/internal/stream"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// scoreboardRequest is a client message on the scoreboard socket
+type scoreboardRequest struct {
+	Action  string   `json:"action"`
+	GameIDs []string `json:"game_ids"`
+}
+
+// Scoreboard upgrades to a WebSocket that sends a "snapshot" of the subscribed NBA games and then
+// an "update" whenever a game's score, status or period changes. Connections start subscribed to
+// the comma separated ?game_ids, or to every game when absent, and can change that by sending
+// {"action": "subscribe" | "unsubscribe", "game_ids": [...]} or {"action": "subscribe_all" |
+// "unsubscribe_all"}, which is answered with a fresh snapshot. Unsubscribing from the last game
+// leaves no games rather than every game. Clients that fall too far behind are sent an "error"
+// and disconnected.
+func (h *StreamHandler) Scoreboard(c *gin.Context) {
+	server := websocket.Server{
+		// browsers always send an Origin; other clients may leave it out
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			var err error
+			config.Origin, err = websocket.Origin(config, req)
+			return err
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			h.serveScoreboard(ws, listQuery(c, "game_ids"))
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func (h *StreamHandler) serveScoreboard(ws *websocket.Conn, gameIDs []string) {
+	subscribe := h.scoreboard.SubscribeAll
+	if len(gameIDs) > 0 {
+		subscribe = func() (*stream.ScoreboardSubscription, []models.Game, func()) {
+			return h.scoreboard.Subscribe(gameIDs...)
+		}
+	}
+	sub, games, cancel := subscribe()
+	defer cancel()
+
+	if sendSocket(ws, gin.H{"type": "snapshot", "games": games}) != nil {
+		return
+	}
+
+	// reads happen on their own goroutine so all writes stay on this one
+	requests := make(chan scoreboardRequest)
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(done)
+		for {
+			var req scoreboardRequest
+			if err := websocket.JSON.Receive(ws, &req); err != nil {
+				return
+			}
+			select {
+			case requests <- req:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case req := <-requests:
+			var msg any
+			switch req.Action {
+			case "subscribe":
+				sub.Subscribe(req.GameIDs...)
+				msg = gin.H{"type": "snapshot", "games": h.scoreboard.Snapshot(sub)}
+			case "unsubscribe":
+				sub.Unsubscribe(req.GameIDs...)
+				msg = gin.H{"type": "snapshot", "games": h.scoreboard.Snapshot(sub)}
+			case "subscribe_all":
+				sub.SubscribeAll()
+				msg = gin.H{"type": "snapshot", "games": h.scoreboard.Snapshot(sub)}
+			case "unsubscribe_all":
+				sub.UnsubscribeAll()
+				msg = gin.H{"type": "snapshot", "games": h.scoreboard.Snapshot(sub)}
+			default:
+				msg = gin.H{"type": "error", "error": "Unknown action: " + req.Action}
+			}
+			if sendSocket(ws, msg) != nil {
+				return
+			}
+		case update, ok := <-sub.Updates:
+			if !ok {
+				sendSocket(ws, gin.H{"type": "error", "error": "Client too slow, reconnect to resume"})
+				return
+			}
+			if sendSocket(ws, update) != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendSocket writes a JSON message, giving up on clients that stop reading
+func sendSocket(ws *websocket.Conn, msg any) error {
+	if err := ws.SetWriteDeadline(time.Now().Add(socketWriteTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(ws, msg)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sports_api/internal/models"
+	"sports_api/internal/stream"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+type scoreboardMessage struct {
+	Type    string        `json:"type"`
+	Games   []models.Game `json:"games"`
+	Changes []string      `json:"changes"`
+	Game    models.Game   `json:"game"`
+}
+
+func TestScoreboardSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	games := []models.Game{{GameID: "1", HomeTeam: "Knicks"}, {GameID: "2", HomeTeam: "Celtics"}}
+	hub := stream.NewScoreboardHub(func() ([]models.Game, error) { return games, nil }, 0)
+	hub.Poll()
+
+	router := gin.New()
+	router.GET("/stream/scoreboard", (&StreamHandler{scoreboard: hub}).Scoreboard)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/stream/scoreboard?game_ids=1"
+	ws, err := websocket.Dial(url, "", server.URL)
+	assert.NoError(t, err)
+	defer ws.Close()
+
+	var msg scoreboardMessage
+	assert.NoError(t, websocket.JSON.Receive(ws, &msg))
+	assert.Equal(t, "snapshot", msg.Type)
+	assert.Len(t, msg.Games, 1)
+	assert.Equal(t, "Knicks", msg.Games[0].HomeTeam)
+
+	assert.NoError(t, websocket.JSON.Send(ws, scoreboardRequest{Action: "subscribe", GameIDs: []string{"2"}}))
+	msg = scoreboardMessage{}
+	assert.NoError(t, websocket.JSON.Receive(ws, &msg))
+	assert.Equal(t, "snapshot", msg.Type)
+	assert.Len(t, msg.Games, 2)
+
+	games = []models.Game{{GameID: "1", HomeTeam: "Knicks", HomeScore: 3}, {GameID: "2", HomeTeam: "Celtics"}}
+	hub.Poll()
+	msg = scoreboardMessage{}
+	assert.NoError(t, websocket.JSON.Receive(ws, &msg))
+	assert.Equal(t, "update", msg.Type)
+	assert.Equal(t, []string{"score"}, msg.Changes)
+	assert.Equal(t, 3, msg.Game.HomeScore)
+
+	assert.NoError(t, websocket.JSON.Send(ws, scoreboardRequest{Action: "refresh"}))
+	msg = scoreboardMessage{}
+	assert.NoError(t, websocket.JSON.Receive(ws, &msg))
+	assert.Equal(t, "error", msg.Type)
+}
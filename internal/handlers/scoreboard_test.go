@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGameClock(t *testing.T) {
+	assert.Equal(t, "5:32", formatGameClock("PT05M32.00S"))
+	assert.Equal(t, "0:04", formatGameClock("PT00M04.70S"))
+	assert.Equal(t, "12:00", formatGameClock("PT12M00.00S"))
+	assert.Equal(t, "", formatGameClock(""))
+	assert.Equal(t, "3:10", formatGameClock("3:10"))
+}
+
+func TestBuildScoreboard(t *testing.T) {
+	start := time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)
+	games := []models.Game{{
+		GameID:     "0022400555",
+		HomeCity:   "Los Angeles",
+		HomeTeam:   "Lakers",
+		AwayCity:   "Golden State",
+		AwayTeam:   "Warriors",
+		StatusCode: 2,
+		GameClock:  "PT07M15.00S",
+		StartTime:  &start,
+	}}
+	odds := map[string][]models.MoneylineOdds{
+		"0022400555": {
+			{Team: "Los Angeles Lakers", Sportbook: "FanDuel", Price: "-150"},
+			{Team: "Golden State Warriors", Sportbook: "FanDuel", Price: "+130"},
+			{Team: "Boston Celtics", Sportbook: "FanDuel", Price: "-300"},
+		},
+		// the Lakers' price for another game isn't attached to this one
+		"0022400500": {
+			{Team: "Los Angeles Lakers", Sportbook: "DraftKings", Price: "+110"},
+		},
+	}
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	assert.NoError(t, err)
+
+	game := buildScoreboard(games, odds, pacific)[0]
+	assert.Equal(t, "live", game.Status)
+	assert.Equal(t, "7:15", game.GameClock)
+	assert.Equal(t, "2025-01-14T19:30:00-08:00", game.StartTime.Format(time.RFC3339))
+	assert.Equal(t, []models.MoneylineOdds{odds["0022400555"][0]}, game.HomeOdds)
+	assert.Equal(t, []models.MoneylineOdds{odds["0022400555"][1]}, game.AwayOdds)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sports_api/internal/database"
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSearchLimit    = 10
+	maxSearchLimit        = 50
+	defaultSearchMinScore = 0.8
+)
+
+// searchIndexMaxAge is how long Search reuses its candidate index before reloading the rosters
+const searchIndexMaxAge = time.Hour
+
+// SearchHandler handles player and team search across sports
+type SearchHandler struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	index     searchIndex
+	indexedAt time.Time
+}
+
+// NewSearchHandler creates a new SearchHandler instance
+func NewSearchHandler(db *sql.DB) *SearchHandler {
+	return &SearchHandler{db: db}
+}
+
+// Search returns players and teams from both sports matching ?q, ranked by match score.
+// Optional ?sport (nba, nfl), ?type (player, team) and ?limit narrow the results.
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := identity.Fold(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Search query q is required",
+		})
+		return
+	}
+
+	limit := defaultSearchLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		n, err := strconv.Atoi(limitStr)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid limit",
+			})
+			return
+		}
+		limit = min(n, maxSearchLimit)
+	}
+
+	index, err := h.searchIndex()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to search",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	results := index.rank(query, strings.ToLower(c.Query("sport")), strings.ToLower(c.Query("type")), limit)
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":   c.Query("q"),
+		"count":   len(results),
+		"results": results,
+	})
+}
+
+// searchIndex returns the index of every player and team Search can match. Loading the rosters
+// and folding every name is shared by all requests, so the index is built once and rebuilt only
+// after searchIndexMaxAge.
+func (h *SearchHandler) searchIndex() (searchIndex, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.index != nil && time.Since(h.indexedAt) < searchIndexMaxAge {
+		return h.index, nil
+	}
+
+	candidates, err := database.GetSearchCandidates(h.db)
+	if err != nil {
+		return nil, err
+	}
+	for sport, teams := range map[string][]models.Team{"nba": identity.NBATeams(), "nfl": identity.NFLTeams()} {
+		for _, team := range teams {
+			candidates = append(candidates, models.SearchResult{
+				Sport: sport,
+				Type:  "team",
+				ID:    team.TeamID,
+				Name:  team.TeamName,
+				Team:  team.Abbr,
+			})
+		}
+	}
+	h.index, h.indexedAt = newSearchIndex(candidates), time.Now()
+	return h.index, nil
+}
+
+// searchIndex holds search candidates with their names already folded, grouped by sport and type
+// so a filtered search only scores the matching group
+type searchIndex map[searchGroup][]searchEntry
+
+type searchGroup struct {
+	sport, kind string
+}
+
+type searchEntry struct {
+	result models.SearchResult
+	name   string
+}
+
+func newSearchIndex(candidates []models.SearchResult) searchIndex {
+	index := searchIndex{}
+	for _, candidate := range candidates {
+		group := searchGroup{sport: candidate.Sport, kind: candidate.Type}
+		index[group] = append(index[group], searchEntry{result: candidate, name: identity.Fold(candidate.Name)})
+	}
+	return index
+}
+
+// rank scores candidates against a normalized query and returns the best limit matches above
+// defaultSearchMinScore. Empty sport or kind match every candidate.
+func (index searchIndex) rank(query, sport, kind string, limit int) []models.SearchResult {
+	results := []models.SearchResult{}
+	for group, entries := range index {
+		if (sport != "" && group.sport != sport) || (kind != "" && group.kind != kind) {
+			continue
+		}
+		for _, entry := range entries {
+			score := matchScore(query, entry.name)
+			if score < defaultSearchMinScore {
+				continue
+			}
+			result := entry.result
+			result.Score = score
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].Sport+results[i].ID < results[j].Sport+results[j].ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// seasonParam reads the optional ?season query param with parse, falling back to def when it is
+// absent. On failure it writes the response, 400 for a bad param and 500 when def fails, and
+// returns false.
+func seasonParam[T any](c *gin.Context, parse func(string) (T, error), def func() (T, error)) (T, bool) {
+	if seasonStr := c.Query("season"); seasonStr != "" {
+		season, err := parse(seasonStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid season parameter",
+				"details": err.Error(),
+			})
+			return season, false
+		}
+		return season, true
+	}
+
+	season, err := def()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to determine the current season",
+			"details": err.Error(),
+		})
+		return season, false
+	}
+	return season, true
+}
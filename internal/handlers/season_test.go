@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeasonParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	latest := func() (int, error) { return 2024, nil }
+	broken := func() (int, error) { return 0, errors.New("connection refused") }
+
+	tests := []struct {
+		name     string
+		query    string
+		def      func() (int, error)
+		want     int
+		wantCode int
+	}{
+		{name: "given", query: "?season=2023", def: broken, want: 2023},
+		{name: "default", query: "", def: latest, want: 2024},
+		{name: "bad param", query: "?season=abc", def: latest, wantCode: http.StatusBadRequest},
+		{name: "default fails", query: "", def: broken, wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/usage"+tt.query, nil)
+
+			season, ok := seasonParam(c, parseNFLSeason, tt.def)
+			if tt.wantCode != 0 {
+				assert.False(t, ok)
+				assert.Equal(t, tt.wantCode, w.Code)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, season)
+		})
+	}
+}
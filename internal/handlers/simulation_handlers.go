@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"sports_api/internal/database"
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+	"sports_api/internal/simulation"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxSimulationLegs = 12
+
+// SimulationHandler handles Monte Carlo simulations of combo props and same-game parlays
+type SimulationHandler struct {
+	db *sql.DB
+}
+
+// NewSimulationHandler creates a new SimulationHandler instance
+func NewSimulationHandler(db *sql.DB) *SimulationHandler {
+	return &SimulationHandler{db: db}
+}
+
+// Simulate draws correlated outcomes for the request's legs from the players' game logs over the
+// past year and returns each leg's probabilities and the chance they all win together. NBA
+// players are given by name or ID, NFL players by gamelog name.
+func (h *SimulationHandler) Simulate(c *gin.Context) {
+	var req models.SimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	legs, err := validateSimulation(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid simulation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	players := map[string]string{}
+	if req.Sport == "nba" {
+		resolver, err := database.NewNBAPlayerResolver(h.db)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to load player identities",
+				"details": err.Error(),
+			})
+			return
+		}
+		if players, err = resolveSimulationPlayers(resolver, legs); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Player not found",
+				"details": err.Error(),
+			})
+			return
+		}
+	} else {
+		for _, leg := range legs {
+			players[leg.Player] = leg.Player
+		}
+	}
+
+	columns := map[string][]string{}
+	for _, leg := range legs {
+		for _, column := range leg.Components {
+			if !slices.Contains(columns[leg.Player], column) {
+				columns[leg.Player] = append(columns[leg.Player], column)
+			}
+		}
+	}
+
+	from := time.Now().AddDate(-1, 0, 0).Format(time.DateOnly)
+	logs := map[string][]models.StatGame{}
+	for player, playerColumns := range columns {
+		games, err := database.GetSimulationGames(h.db, req.Sport, players[player], playerColumns, from)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve game logs",
+				"details": err.Error(),
+			})
+			return
+		}
+		if req.Games > 0 && len(games) > req.Games {
+			games = games[len(games)-req.Games:]
+		}
+		logs[player] = games
+	}
+
+	result, err := simulation.Simulate(logs, legs, req.Iterations, *req.Seed)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "Failed to simulate",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// validateSimulation checks a simulation request, normalizing sport and sides, defaulting the
+// iterations and generating a seed when none was given, and returns its legs with the gamelog
+// columns each one adds up
+func validateSimulation(req *models.SimulationRequest) ([]simulation.Leg, error) {
+	req.Sport = strings.ToLower(strings.TrimSpace(req.Sport))
+	if req.Sport != "nba" && req.Sport != "nfl" {
+		return nil, fmt.Errorf("sport must be nba or nfl")
+	}
+	if len(req.Legs) == 0 || len(req.Legs) > maxSimulationLegs {
+		return nil, fmt.Errorf("between 1 and %d legs are required", maxSimulationLegs)
+	}
+	if req.Iterations == 0 {
+		req.Iterations = simulation.DefaultIterations
+	}
+	if req.Iterations < 0 || req.Iterations > simulation.MaxIterations {
+		return nil, fmt.Errorf("iterations must be between 1 and %d", simulation.MaxIterations)
+	}
+	if req.Games < 0 {
+		return nil, fmt.Errorf("games must not be negative")
+	}
+	if req.Seed == nil {
+		seed := rand.Uint64()
+		req.Seed = &seed
+	}
+
+	legs := make([]simulation.Leg, len(req.Legs))
+	for i, leg := range req.Legs {
+		leg.Player = strings.TrimSpace(leg.Player)
+		if leg.Player == "" {
+			return nil, fmt.Errorf("leg %d: player is required", i+1)
+		}
+		components, ok := database.MarketComponents(req.Sport, leg.Market)
+		if !ok {
+			return nil, fmt.Errorf("leg %d: market must be one of %s", i+1, strings.Join(database.BetMarkets(req.Sport), ", "))
+		}
+		leg.Side = strings.ToLower(strings.TrimSpace(leg.Side))
+		if leg.Side != "over" && leg.Side != "under" {
+			return nil, fmt.Errorf("leg %d: side must be over or under", i+1)
+		}
+		req.Legs[i] = leg
+		legs[i] = simulation.Leg{SimulationLeg: leg, Components: components}
+	}
+	return legs, nil
+}
+
+// resolveSimulationPlayers maps each leg's NBA player, given by name or ID, to a player ID
+func resolveSimulationPlayers(resolver *identity.Resolver, legs []simulation.Leg) (map[string]string, error) {
+	players := map[string]string{}
+	for _, leg := range legs {
+		if _, ok := players[leg.Player]; ok {
+			continue
+		}
+		id, ok := resolveNBAPlayer(resolver, leg.Player)
+		if !ok {
+			return nil, fmt.Errorf("no NBA player matches %s", leg.Player)
+		}
+		players[leg.Player] = id
+	}
+	return players, nil
+}
+
+// resolveNBAPlayer resolves a player name to an ID, accepting a numeric player ID as is
+func resolveNBAPlayer(resolver *identity.Resolver, player string) (string, bool) {
+	if id, ok := resolver.Resolve(player); ok {
+		return id, true
+	}
+	if player != "" && strings.Trim(player, "0123456789") == "" {
+		return player, true
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+	"sports_api/internal/simulation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSimulation(t *testing.T) {
+	seed := uint64(9)
+	req := models.SimulationRequest{
+		Sport: " NBA ",
+		Legs: []models.SimulationLeg{
+			{Player: "LeBron James", Market: "player_points_rebounds_assists", Side: "Over", Line: 40.5},
+			{Player: "Anthony Davis", Market: "player_rebounds", Side: "under", Line: 12.5},
+		},
+		Seed: &seed,
+	}
+
+	legs, err := validateSimulation(&req)
+	require.NoError(t, err)
+	assert.Equal(t, "nba", req.Sport)
+	assert.Equal(t, simulation.DefaultIterations, req.Iterations)
+	assert.Equal(t, uint64(9), *req.Seed)
+	require.Len(t, legs, 2)
+	assert.Equal(t, "over", legs[0].Side)
+	assert.Equal(t, []string{"points", "reboundsTotal", "assists"}, legs[0].Components)
+	assert.Equal(t, []string{"reboundsTotal"}, legs[1].Components)
+
+	nfl := models.SimulationRequest{
+		Sport: "nfl",
+		Legs:  []models.SimulationLeg{{Player: "Derrick Henry", Market: "player_rush_reception_yds", Side: "over", Line: 110.5}},
+	}
+	legs, err = validateSimulation(&nfl)
+	require.NoError(t, err)
+	require.NotNil(t, nfl.Seed)
+	assert.Equal(t, []string{"rushingYards", "receivingYards"}, legs[0].Components)
+
+	invalid := []models.SimulationRequest{
+		{Sport: "mlb", Legs: req.Legs},
+		{Sport: "nba"},
+		{Sport: "nba", Legs: req.Legs, Iterations: simulation.MaxIterations + 1},
+		{Sport: "nba", Legs: req.Legs, Games: -1},
+		{Sport: "nba", Legs: []models.SimulationLeg{{Player: "LeBron James", Market: "player_pass_yds", Side: "over"}}},
+		{Sport: "nba", Legs: []models.SimulationLeg{{Player: "LeBron James", Market: "player_points", Side: "yes"}}},
+		{Sport: "nba", Legs: []models.SimulationLeg{{Player: " ", Market: "player_points", Side: "over"}}},
+	}
+	for _, req := range invalid {
+		_, err := validateSimulation(&req)
+		assert.Error(t, err, "%+v", req)
+	}
+}
+
+func TestResolveSimulationPlayers(t *testing.T) {
+	resolver := identity.NewResolver(map[string]string{"2544": "LeBron James"}, nil)
+	leg := func(player string) simulation.Leg {
+		return simulation.Leg{SimulationLeg: models.SimulationLeg{Player: player}}
+	}
+
+	players, err := resolveSimulationPlayers(resolver, []simulation.Leg{leg("Lebron James"), leg("203076"), leg("Lebron James")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Lebron James": "2544", "203076": "203076"}, players)
+
+	_, err = resolveSimulationPlayers(resolver, []simulation.Leg{leg("Nobody Here")})
+	assert.Error(t, err)
+}
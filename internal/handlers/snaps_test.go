@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapShareMovers(t *testing.T) {
+	week := func(player string, priorWeek int, change float64) models.NFLSnapCountWeek {
+		return models.NFLSnapCountWeek{PlayerName: player, Week: 5, PriorWeek: priorWeek, SnapPctChange: change}
+	}
+	names := func(weeks []models.NFLSnapCountWeek) []string {
+		out := []string{}
+		for _, w := range weeks {
+			out = append(out, w.PlayerName)
+		}
+		return out
+	}
+	weeks := []models.NFLSnapCountWeek{
+		week("small riser", 4, 0.05),
+		week("big faller", 4, -0.40),
+		week("rookie debut", 0, 0.90),
+		week("unchanged", 4, 0),
+		week("big riser", 3, 0.35),
+		week("small faller", 4, -0.10),
+		week("mid riser", 4, 0.20),
+	}
+
+	risers, fallers := snapShareMovers(weeks, 10)
+	assert.Equal(t, []string{"big riser", "mid riser", "small riser"}, names(risers))
+	assert.Equal(t, []string{"big faller", "small faller"}, names(fallers))
+
+	risers, fallers = snapShareMovers(weeks, 1)
+	assert.Equal(t, []string{"big riser"}, names(risers))
+	assert.Equal(t, []string{"big faller"}, names(fallers))
+
+	risers, fallers = snapShareMovers([]models.NFLSnapCountWeek{week("unchanged", 4, 0), week("rookie debut", 0, -0.5)}, 10)
+	assert.Empty(t, risers)
+	assert.Empty(t, fallers)
+}
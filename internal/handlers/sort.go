@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// sortByJSONField sorts items in place by the struct field whose json tag matches field.
+// Numeric and string fields, and pointers to them, are supported; nil pointers sort last in
+// either order and ties keep their original order.
+func sortByJSONField[T any](items []T, field string, desc bool) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot sort %s by field", t)
+	}
+
+	index := -1
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == field {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("unknown sort field: %s", field)
+	}
+
+	ft := t.Field(index).Type
+	pointer := ft.Kind() == reflect.Pointer
+	if pointer {
+		ft = ft.Elem()
+	}
+
+	var less func(a, b reflect.Value) bool
+	switch ft.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+	default:
+		return fmt.Errorf("field %s is not sortable", field)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a := reflect.ValueOf(items[i]).Field(index)
+		b := reflect.ValueOf(items[j]).Field(index)
+		if pointer {
+			if a.IsNil() || b.IsNil() {
+				return !a.IsNil() && b.IsNil()
+			}
+			a, b = a.Elem(), b.Elem()
+		}
+		if desc {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+	return nil
+}
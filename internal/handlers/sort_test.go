@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sortRow struct {
+	Team string  `json:"team_name"`
+	Rank int     `json:"rank"`
+	Rate float64 `json:"rate,omitempty"`
+}
+
+func TestSortByJSONField(t *testing.T) {
+	rows := []sortRow{
+		{Team: "B", Rank: 2, Rate: 0.5},
+		{Team: "A", Rank: 3, Rate: 0.1},
+		{Team: "C", Rank: 1, Rate: 0.9},
+	}
+
+	assert.NoError(t, sortByJSONField(rows, "rank", false))
+	assert.Equal(t, []string{"C", "B", "A"}, []string{rows[0].Team, rows[1].Team, rows[2].Team})
+
+	assert.NoError(t, sortByJSONField(rows, "rate", true))
+	assert.Equal(t, []string{"C", "B", "A"}, []string{rows[0].Team, rows[1].Team, rows[2].Team})
+
+	assert.NoError(t, sortByJSONField(rows, "team_name", false))
+	assert.Equal(t, []string{"A", "B", "C"}, []string{rows[0].Team, rows[1].Team, rows[2].Team})
+}
+
+func TestSortByJSONField_NilLast(t *testing.T) {
+	type rankRow struct {
+		Team string `json:"team_name"`
+		Rank *int   `json:"rank"`
+	}
+	one, two := 1, 2
+	rows := []rankRow{{Team: "A"}, {Team: "B", Rank: &two}, {Team: "C", Rank: &one}}
+
+	assert.NoError(t, sortByJSONField(rows, "rank", false))
+	assert.Equal(t, []string{"C", "B", "A"}, []string{rows[0].Team, rows[1].Team, rows[2].Team})
+
+	assert.NoError(t, sortByJSONField(rows, "rank", true))
+	assert.Equal(t, []string{"B", "C", "A"}, []string{rows[0].Team, rows[1].Team, rows[2].Team})
+}
+
+func TestSortByJSONField_UnknownField(t *testing.T) {
+	rows := []sortRow{{Team: "A"}}
+	assert.Error(t, sortByJSONField(rows, "nope", false))
+}
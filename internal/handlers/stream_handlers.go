@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+	"sports_api/internal/stream"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	oddsPollInterval       = 30 * time.Second
+	scoreboardPollInterval = 10 * time.Second
+	heartbeatInterval      = 15 * time.Second
+	socketWriteTimeout     = 10 * time.Second
+)
+
+// StreamHandler handles Server-Sent Events and WebSocket streams
+type StreamHandler struct {
+	odds       *stream.OddsHub
+	scoreboard *stream.ScoreboardHub
+}
+
+// NewOddsHub creates the hub that polls prop odds for both sports and detects line changes
+func NewOddsHub(db *sql.DB) *stream.OddsHub {
+	source := func(sport string) ([]models.Odds, error) {
+		return database.GetLatestPropOdds(db, sport)
+	}
+	return stream.NewOddsHub(source, database.PropOddsSports(), oddsPollInterval)
+}
+
+// NewStreamHandler creates a new StreamHandler instance streaming odds changes from hub
+func NewStreamHandler(db *sql.DB, hub *stream.OddsHub) *StreamHandler {
+	scoreboard := func() ([]models.Game, error) {
+		games, err := database.GetScoreboard(db, "")
+		if err != nil {
+			return nil, err
+		}
+		return buildScoreboard(games, nil, time.UTC), nil
+	}
+	return &StreamHandler{
+		odds:       hub,
+		scoreboard: stream.NewScoreboardHub(scoreboard, scoreboardPollInterval),
+	}
+}
+
+// Odds streams prop line and price changes as "odds" events. Optional comma separated ?sport,
+// ?player, ?market and ?book filter the stream. Clients resuming with a Last-Event-ID header (or
+// ?last_event_id) first receive the buffered changes they missed. When those changes are gone,
+// because the server restarted or the ID is older than the replay buffer, a "reset" event is sent
+// first instead and the client should reload the current odds. A comment line is sent every
+// heartbeatInterval to keep idle connections open.
+func (h *StreamHandler) Odds(c *gin.Context) {
+	filter := stream.OddsFilter{
+		Sports:  listQuery(c, "sport"),
+		Players: listQuery(c, "player"),
+		Markets: listQuery(c, "market"),
+		Books:   listQuery(c, "book"),
+	}
+	for _, sport := range filter.Sports {
+		if !strings.EqualFold(sport, "nba") && !strings.EqualFold(sport, "nfl") {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid sport: " + sport,
+			})
+			return
+		}
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	replay, resetID, changes, cancel := h.odds.Subscribe(filter, lastEventID)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if resetID != "" {
+		c.Render(-1, sse.Event{
+			Id:    resetID,
+			Event: "reset",
+			Data:  gin.H{"reason": "Last-Event-ID " + lastEventID + " can no longer be resumed; reload the current odds"},
+		})
+	}
+	for _, change := range replay {
+		h.renderOddsChange(c, change)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			h.renderOddsChange(c, change)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func (h *StreamHandler) renderOddsChange(c *gin.Context, change models.OddsChange) {
+	c.Render(-1, sse.Event{
+		Id:    h.odds.EventID(change),
+		Event: "odds",
+		Data:  change,
+	})
+}
+
+// listQuery splits a comma separated query param, dropping empty entries
+func listQuery(c *gin.Context, param string) []string {
+	var values []string
+	for _, v := range strings.Split(c.Query(param), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"strings"
+
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+)
+
+// nbaTeamParam resolves a team given as an ID, city, nickname, full name or abbreviation.
+// Input the registry doesn't know passes through unchanged in every field.
+func nbaTeamParam(s string) models.Team {
+	s = strings.TrimSpace(s)
+	if team, ok := identity.LookupNBATeam(s); ok {
+		return team
+	}
+	return models.Team{TeamID: s, TeamName: s, City: s, Nickname: s, Abbr: s}
+}
+
+// nflTeamParam resolves a team given as an ID, city, nickname, full name or abbreviation.
+// Input the registry doesn't know passes through unchanged in every field.
+func nflTeamParam(s string) models.Team {
+	s = strings.TrimSpace(s)
+	if team, ok := identity.LookupNFLTeam(s); ok {
+		return team
+	}
+	return models.Team{TeamID: s, TeamName: s, City: s, Nickname: s, Abbr: s}
+}
+
+// sameNBATeam reports whether two team references in any form name the same team
+func sameNBATeam(a, b string) bool {
+	return strings.EqualFold(nbaTeamParam(a).TeamID, nbaTeamParam(b).TeamID)
+}
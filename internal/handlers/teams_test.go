@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamParam(t *testing.T) {
+	assert.Equal(t, "Boston", nbaTeamParam("BOS").City)
+	assert.Equal(t, "Boston Celtics", nbaTeamParam(" celtics ").TeamName)
+	assert.Equal(t, "Kansas City Chiefs", nflTeamParam("KC").TeamName)
+	assert.Equal(t, "KC", nflTeamParam("Chiefs").Abbr)
+
+	// unknown teams pass through unchanged
+	assert.Equal(t, "Seattle SuperSonics", nbaTeamParam("Seattle SuperSonics").TeamName)
+	assert.Equal(t, "New York", nflTeamParam("New York").City)
+}
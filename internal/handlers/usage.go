@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"sort"
+
+	"sports_api/internal/models"
+)
+
+// computeUsageRates fills the share and efficiency fields from the raw counts.
+// WOPR is 1.5 x target share + 0.7 x air yards share; yards per route uses offense snaps
+// as the route count since route data isn't available.
+func computeUsageRates(u *models.NFLReceiverUsage) {
+	u.TargetShare, u.AirYardsShare, u.WOPR, u.ADOT, u.YardsPerRouteProxy = 0, 0, 0, 0, 0
+	if u.TeamTargets > 0 {
+		u.TargetShare = float64(u.Targets) / float64(u.TeamTargets)
+	}
+	if u.TeamAirYards != 0 {
+		u.AirYardsShare = u.AirYards / u.TeamAirYards
+	}
+	u.WOPR = 1.5*u.TargetShare + 0.7*u.AirYardsShare
+	if u.Targets > 0 {
+		u.ADOT = u.AirYards / float64(u.Targets)
+	}
+	if u.OffenseSnaps > 0 {
+		u.YardsPerRouteProxy = float64(u.ReceivingYards) / float64(u.OffenseSnaps)
+	}
+}
+
+// summarizeUsage rolls weekly usage up to one season row per player, ordered by WOPR descending
+func summarizeUsage(weeks []models.NFLReceiverUsage) []models.NFLReceiverUsage {
+	byPlayer := make(map[string]*models.NFLReceiverUsage)
+	var order []string
+	for _, w := range weeks {
+		total, ok := byPlayer[w.PlayerName]
+		if !ok {
+			total = &models.NFLReceiverUsage{PlayerName: w.PlayerName}
+			byPlayer[w.PlayerName] = total
+			order = append(order, w.PlayerName)
+		}
+		// weeks are in order, so a traded player ends up with their latest team
+		total.TeamName = w.TeamName
+		total.Games++
+		total.Targets += w.Targets
+		total.Receptions += w.Receptions
+		total.ReceivingYards += w.ReceivingYards
+		total.OffenseSnaps += w.OffenseSnaps
+		total.AirYards += w.AirYards
+		total.TeamTargets += w.TeamTargets
+		total.TeamAirYards += w.TeamAirYards
+	}
+
+	seasons := make([]models.NFLReceiverUsage, 0, len(order))
+	for _, name := range order {
+		total := byPlayer[name]
+		computeUsageRates(total)
+		seasons = append(seasons, *total)
+	}
+	sort.SliceStable(seasons, func(i, j int) bool { return seasons[i].WOPR > seasons[j].WOPR })
+	return seasons
+}
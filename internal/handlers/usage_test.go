@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeUsageRates(t *testing.T) {
+	u := models.NFLReceiverUsage{
+		Targets:        10,
+		ReceivingYards: 90,
+		OffenseSnaps:   45,
+		AirYards:       120,
+		TeamTargets:    40,
+		TeamAirYards:   300,
+	}
+	computeUsageRates(&u)
+
+	assert.InDelta(t, 0.25, u.TargetShare, 1e-9)
+	assert.InDelta(t, 0.4, u.AirYardsShare, 1e-9)
+	assert.InDelta(t, 1.5*0.25+0.7*0.4, u.WOPR, 1e-9)
+	assert.InDelta(t, 12.0, u.ADOT, 1e-9)
+	assert.InDelta(t, 2.0, u.YardsPerRouteProxy, 1e-9)
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	weeks := []models.NFLReceiverUsage{
+		{PlayerName: "A", Week: 1, Targets: 2, TeamTargets: 30, AirYards: 10, TeamAirYards: 200},
+		{PlayerName: "B", Week: 1, Targets: 10, TeamTargets: 30, AirYards: 100, TeamAirYards: 200},
+		{PlayerName: "B", Week: 2, Targets: 8, TeamTargets: 30, AirYards: 60, TeamAirYards: 200},
+	}
+
+	seasons := summarizeUsage(weeks)
+	assert.Len(t, seasons, 2)
+	assert.Equal(t, "B", seasons[0].PlayerName)
+	assert.Equal(t, 2, seasons[0].Games)
+	assert.Equal(t, 18, seasons[0].Targets)
+	assert.InDelta(t, 0.3, seasons[0].TargetShare, 1e-9)
+	assert.InDelta(t, 0.4, seasons[0].AirYardsShare, 1e-9)
+}
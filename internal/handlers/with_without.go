@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"sports_api/internal/database"
+	"sports_api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWithWithoutSplits reports each player's averages in the team's games where a set of absent
+// teammates didn't play versus games where they all did. ?absent takes a comma separated list of
+// player IDs or names; without it the players listed Out on the team's latest injury report are
+// used. Games where only some of the absent players played fall in neither split.
+func (h *NBAHandler) GetWithWithoutSplits(c *gin.Context) {
+	team := nbaTeamParam(c.Param("team_name"))
+	if team.City == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Team is required",
+		})
+		return
+	}
+
+	seasonID, ok := h.seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	resolver, err := database.NewNBAPlayerResolver(h.db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load player identities",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	var names []string
+	if absent := c.Query("absent"); absent != "" {
+		names = strings.Split(absent, ",")
+	} else {
+		injuries, err := database.GetNBALatestInjuries(h.db)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to retrieve injuries",
+				"details": err.Error(),
+			})
+			return
+		}
+		for _, injury := range injuries {
+			if sameNBATeam(injury.Team, team.TeamID) && strings.EqualFold(strings.TrimSpace(injury.Status), "Out") {
+				names = append(names, injury.PlayerName)
+			}
+		}
+	}
+
+	var absentIDs, unmatched []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if id, ok := resolveNBAPlayer(resolver, name); ok {
+			absentIDs = append(absentIDs, id)
+		} else {
+			unmatched = append(unmatched, name)
+		}
+	}
+	if len(unmatched) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":     "Unknown absent players",
+			"unmatched": unmatched,
+		})
+		return
+	}
+	if len(absentIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No absent players given and none are listed Out for " + team.TeamName,
+		})
+		return
+	}
+
+	lines, err := database.GetNBATeamGameLines(h.db, c.Param("team_id"), seasonID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team game lines",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	splits, gamesWith, gamesWithout := withWithoutSplits(lines, absentIDs)
+	c.JSON(http.StatusOK, gin.H{
+		"team":          team.TeamName,
+		"season":        seasonID,
+		"absent":        absentIDs,
+		"games_with":    gamesWith,
+		"games_without": gamesWithout,
+		"players":       splits,
+	})
+}
+
+// withWithoutSplits buckets each game by whether all absent players played (with) or none did
+// (without) and averages every other player's lines in each bucket. Partial games are skipped.
+// Players are ordered by minutes without the absent players, then by minutes with them.
+func withWithoutSplits(lines []models.NBAPlayerGameLine, absentIDs []string) ([]models.NBAWithWithoutSplit, int, int) {
+	absent := make(map[string]bool, len(absentIDs))
+	for _, id := range absentIDs {
+		absent[id] = true
+	}
+
+	played := make(map[string]int)
+	for _, line := range lines {
+		if absent[line.PlayerID] {
+			played[line.GameID]++
+		} else if _, ok := played[line.GameID]; !ok {
+			played[line.GameID] = 0
+		}
+	}
+
+	gamesWith, gamesWithout := 0, 0
+	for _, n := range played {
+		switch n {
+		case 0:
+			gamesWithout++
+		case len(absent):
+			gamesWith++
+		}
+	}
+
+	splits := []models.NBAWithWithoutSplit{}
+	index := make(map[string]int)
+	for _, line := range lines {
+		if absent[line.PlayerID] {
+			continue
+		}
+		n := played[line.GameID]
+		if n != 0 && n != len(absent) {
+			continue
+		}
+
+		i, ok := index[line.PlayerID]
+		if !ok {
+			i = len(splits)
+			index[line.PlayerID] = i
+			splits = append(splits, models.NBAWithWithoutSplit{PlayerID: line.PlayerID, PlayerName: line.PlayerName})
+		}
+		avg := &splits[i].With
+		if n == 0 {
+			avg = &splits[i].Without
+		}
+		avg.Games++
+		avg.Points += line.Points
+		avg.Assists += line.Assists
+		avg.Rebounds += line.Rebounds
+		avg.Minutes += line.Minutes
+	}
+
+	for i := range splits {
+		for _, avg := range []*models.NBASplitAverages{&splits[i].With, &splits[i].Without} {
+			if avg.Games == 0 {
+				continue
+			}
+			n := float64(avg.Games)
+			avg.Points /= n
+			avg.Assists /= n
+			avg.Rebounds /= n
+			avg.Minutes /= n
+		}
+	}
+
+	sort.SliceStable(splits, func(i, j int) bool {
+		if splits[i].Without.Minutes != splits[j].Without.Minutes {
+			return splits[i].Without.Minutes > splits[j].Without.Minutes
+		}
+		return splits[i].With.Minutes > splits[j].With.Minutes
+	})
+	return splits, gamesWith, gamesWithout
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"testing"
+
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWithoutSplits(t *testing.T) {
+	line := func(game, player string, points, minutes float64) models.NBAPlayerGameLine {
+		return models.NBAPlayerGameLine{GameID: game, PlayerID: player, PlayerName: "P" + player, Points: points, Minutes: minutes}
+	}
+	lines := []models.NBAPlayerGameLine{
+		// both absent players played
+		line("g1", "star", 30, 36), line("g1", "big", 12, 30), line("g1", "guard", 10, 24),
+		line("g2", "star", 28, 35), line("g2", "big", 10, 28), line("g2", "guard", 14, 26),
+		// neither played
+		line("g3", "guard", 24, 36),
+		line("g4", "guard", 20, 34), line("g4", "bench", 8, 20),
+		// only one played: skipped
+		line("g5", "star", 25, 34), line("g5", "guard", 40, 40),
+	}
+
+	splits, gamesWith, gamesWithout := withWithoutSplits(lines, []string{"star", "big"})
+	assert.Equal(t, 2, gamesWith)
+	assert.Equal(t, 2, gamesWithout)
+	assert.Len(t, splits, 2)
+
+	guard := splits[0]
+	assert.Equal(t, "guard", guard.PlayerID)
+	assert.Equal(t, models.NBASplitAverages{Games: 2, Points: 12, Minutes: 25}, guard.With)
+	assert.Equal(t, models.NBASplitAverages{Games: 2, Points: 22, Minutes: 35}, guard.Without)
+
+	bench := splits[1]
+	assert.Equal(t, "bench", bench.PlayerID)
+	assert.Equal(t, 0, bench.With.Games)
+	assert.Equal(t, models.NBASplitAverages{Games: 1, Points: 8, Minutes: 20}, bench.Without)
+}
+
+func TestResolveNBAPlayerAcceptsIDs(t *testing.T) {
+	resolver := identity.NewResolver(map[string]string{"1628991": "Jaren Jackson Jr."}, nil)
+	for _, player := range []string{"Jaren Jackson", "1628991", "203076"} {
+		_, ok := resolveNBAPlayer(resolver, player)
+		assert.True(t, ok, player)
+	}
+	id, _ := resolveNBAPlayer(resolver, "jaren jackson jr")
+	assert.Equal(t, "1628991", id)
+	_, ok := resolveNBAPlayer(resolver, "Nobody Here")
+	assert.False(t, ok)
+}
@@ -0,0 +1,141 @@
+// Package identity resolves the player names used by the roster, injury and odds sources to a
+// single canonical player ID.
+package identity
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// suffixes are generational suffixes dropped by Canonical
+var suffixes = map[string]bool{
+	"jr": true, "sr": true, "ii": true, "iii": true, "iv": true, "v": true,
+}
+
+// nicknames maps a first name as it appears in some sources to the roster's given name
+var nicknames = map[string]string{
+	"cam":  "cameron",
+	"gg":   "gregory",
+	"herb": "herbert",
+	"kj":   "kenyon",
+	"moe":  "moritz",
+	"nic":  "nicolas",
+	"og":   "ogugua",
+}
+
+// Fold lowercases a name, strips accents and drops punctuation such as periods and apostrophes,
+// so "Nikola Jokić" and "nikola jokic" or "De'Aaron" and "deaaron" compare equal. Hyphens and
+// runs of whitespace become a single space.
+func Fold(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining accent left over from decomposition
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+			space = false
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			if !space && b.Len() > 0 {
+				b.WriteByte(' ')
+				space = true
+			}
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Canonical is Fold plus the player name rules: "Last, First" is reordered, generational
+// suffixes are dropped and known nicknames are expanded, so "Jackson Jr., Jaren" and
+// "Jaren Jackson Jr" both become "jaren jackson".
+func Canonical(name string) string {
+	if last, first, ok := strings.Cut(name, ","); ok {
+		// a trailing ", Jr." is a suffix, not a "Last, First" name
+		if !suffixes[Fold(first)] {
+			name = first + " " + last
+		}
+	}
+
+	words := strings.Fields(Fold(name))
+	kept := words[:0]
+	for _, w := range words {
+		if !suffixes[w] {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) > 1 {
+		if given, ok := nicknames[kept[0]]; ok {
+			kept[0] = given
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// Resolver maps names from any source to player IDs using the roster's canonical names plus
+// an alias table for cases the rules can't handle.
+type Resolver struct {
+	byName           map[string]string
+	ambiguous        map[string]bool
+	aliases          map[string]string
+	canonicalAliases map[string]string
+}
+
+// NewResolver indexes roster players (ID to display name) and aliases (any name form to ID).
+// Canonical names shared by more than one roster player are left unresolved unless the exact
+// name is aliased.
+func NewResolver(roster map[string]string, aliases map[string]string) *Resolver {
+	r := &Resolver{
+		byName:           make(map[string]string, len(roster)),
+		ambiguous:        make(map[string]bool),
+		aliases:          make(map[string]string, len(aliases)),
+		canonicalAliases: make(map[string]string, len(aliases)),
+	}
+	for id, name := range roster {
+		key := Canonical(name)
+		if existing, ok := r.byName[key]; ok && existing != id {
+			r.ambiguous[key] = true
+			continue
+		}
+		r.byName[key] = id
+	}
+	for alias, id := range aliases {
+		r.aliases[Fold(alias)] = id
+		r.canonicalAliases[Canonical(alias)] = id
+	}
+	return r
+}
+
+// Resolve returns the player ID for a name from any source
+func (r *Resolver) Resolve(name string) (string, bool) {
+	if id, ok := r.aliases[Fold(name)]; ok {
+		return id, true
+	}
+	key := Canonical(name)
+	if r.ambiguous[key] {
+		return "", false
+	}
+	if id, ok := r.byName[key]; ok {
+		return id, true
+	}
+	id, ok := r.canonicalAliases[key]
+	return id, ok
+}
+
+// Unmatched returns the names that don't resolve, in their original order without duplicates
+func (r *Resolver) Unmatched(names []string) []string {
+	seen := make(map[string]bool)
+	unmatched := []string{}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, ok := r.Resolve(name); !ok {
+			unmatched = append(unmatched, name)
+		}
+	}
+	return unmatched
+}
@@ -0,0 +1,60 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "nikola jokic", Fold("Nikola Jokić"))
+	assert.Equal(t, "deaaron fox", Fold("De'Aaron  Fox"))
+	assert.Equal(t, "amon ra st brown", Fold("Amon-Ra St. Brown"))
+	assert.Equal(t, "", Fold("  "))
+}
+
+func TestCanonical(t *testing.T) {
+	tests := map[string]string{
+		"Jaren Jackson Jr.":   "jaren jackson",
+		"Jackson Jr., Jaren":  "jaren jackson",
+		"Jokic, Nikola":       "nikola jokic",
+		"Gary Trent, Jr.":     "gary trent",
+		"Herb Jones":          "herbert jones",
+		"Nic Claxton":         "nicolas claxton",
+		"Robert Williams III": "robert williams",
+		"Cam":                 "cam",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, Canonical(in), in)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	roster := map[string]string{
+		"1629029": "Luka Dončić",
+		"1628991": "Jaren Jackson Jr.",
+		"1630178": "Herbert Jones",
+		"1":       "Marcus Morris",
+		"2":       "Marcus Morris Sr.",
+	}
+	aliases := map[string]string{
+		"Marcus Morris Sr.": "2",
+		"Jimmy Butler III":  "202710",
+	}
+	r := NewResolver(roster, aliases)
+
+	for name, want := range map[string]string{
+		"Luka Doncic":        "1629029",
+		"Jackson Jr., Jaren": "1628991",
+		"Herb Jones":         "1630178",
+		"Marcus Morris Sr.":  "2",
+		"Jimmy Butler":       "202710",
+	} {
+		id, ok := r.Resolve(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, id, name)
+	}
+
+	assert.Equal(t, []string{"Marcus Morris", "Unknown Player"},
+		r.Unmatched([]string{"Luka Doncic", "Marcus Morris", "Unknown Player", "Unknown Player"}))
+}
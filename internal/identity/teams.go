@@ -0,0 +1,170 @@
+package identity
+
+import (
+	"sports_api/internal/models"
+)
+
+// teamRegistry finds a team from any of its ID, city, nickname, full name, abbreviation or alias
+type teamRegistry struct {
+	teams []models.Team
+	index map[string]int
+}
+
+func newTeamRegistry(teams []models.Team, aliases map[string]string) *teamRegistry {
+	r := &teamRegistry{teams: teams, index: make(map[string]int)}
+	ambiguous := make(map[string]bool)
+	for i, t := range teams {
+		for _, key := range []string{t.TeamID, t.TeamName, t.City, t.Nickname, t.Abbr} {
+			key = Fold(key)
+			if existing, ok := r.index[key]; ok && existing != i {
+				// "Los Angeles" and "New York" name more than one team
+				ambiguous[key] = true
+				continue
+			}
+			r.index[key] = i
+		}
+	}
+	for key := range ambiguous {
+		delete(r.index, key)
+	}
+	for alias, abbr := range aliases {
+		// an alias for an unknown team is skipped; TestTeamAliasesResolve catches the typo
+		if i, ok := r.index[Fold(abbr)]; ok {
+			r.index[Fold(alias)] = i
+		}
+	}
+	return r
+}
+
+func (r *teamRegistry) lookup(s string) (models.Team, bool) {
+	i, ok := r.index[Fold(s)]
+	if !ok {
+		return models.Team{}, false
+	}
+	return r.teams[i], true
+}
+
+func (r *teamRegistry) all() []models.Team {
+	return append([]models.Team(nil), r.teams...)
+}
+
+var nbaTeams = newTeamRegistry([]models.Team{
+	{TeamID: "1610612737", City: "Atlanta", Nickname: "Hawks", TeamName: "Atlanta Hawks", Abbr: "ATL"},
+	{TeamID: "1610612738", City: "Boston", Nickname: "Celtics", TeamName: "Boston Celtics", Abbr: "BOS"},
+	{TeamID: "1610612751", City: "Brooklyn", Nickname: "Nets", TeamName: "Brooklyn Nets", Abbr: "BKN"},
+	{TeamID: "1610612766", City: "Charlotte", Nickname: "Hornets", TeamName: "Charlotte Hornets", Abbr: "CHA"},
+	{TeamID: "1610612741", City: "Chicago", Nickname: "Bulls", TeamName: "Chicago Bulls", Abbr: "CHI"},
+	{TeamID: "1610612739", City: "Cleveland", Nickname: "Cavaliers", TeamName: "Cleveland Cavaliers", Abbr: "CLE"},
+	{TeamID: "1610612742", City: "Dallas", Nickname: "Mavericks", TeamName: "Dallas Mavericks", Abbr: "DAL"},
+	{TeamID: "1610612743", City: "Denver", Nickname: "Nuggets", TeamName: "Denver Nuggets", Abbr: "DEN"},
+	{TeamID: "1610612765", City: "Detroit", Nickname: "Pistons", TeamName: "Detroit Pistons", Abbr: "DET"},
+	{TeamID: "1610612744", City: "Golden State", Nickname: "Warriors", TeamName: "Golden State Warriors", Abbr: "GSW"},
+	{TeamID: "1610612745", City: "Houston", Nickname: "Rockets", TeamName: "Houston Rockets", Abbr: "HOU"},
+	{TeamID: "1610612754", City: "Indiana", Nickname: "Pacers", TeamName: "Indiana Pacers", Abbr: "IND"},
+	{TeamID: "1610612746", City: "LA", Nickname: "Clippers", TeamName: "LA Clippers", Abbr: "LAC"},
+	{TeamID: "1610612747", City: "Los Angeles", Nickname: "Lakers", TeamName: "Los Angeles Lakers", Abbr: "LAL"},
+	{TeamID: "1610612763", City: "Memphis", Nickname: "Grizzlies", TeamName: "Memphis Grizzlies", Abbr: "MEM"},
+	{TeamID: "1610612748", City: "Miami", Nickname: "Heat", TeamName: "Miami Heat", Abbr: "MIA"},
+	{TeamID: "1610612749", City: "Milwaukee", Nickname: "Bucks", TeamName: "Milwaukee Bucks", Abbr: "MIL"},
+	{TeamID: "1610612750", City: "Minnesota", Nickname: "Timberwolves", TeamName: "Minnesota Timberwolves", Abbr: "MIN"},
+	{TeamID: "1610612740", City: "New Orleans", Nickname: "Pelicans", TeamName: "New Orleans Pelicans", Abbr: "NOP"},
+	{TeamID: "1610612752", City: "New York", Nickname: "Knicks", TeamName: "New York Knicks", Abbr: "NYK"},
+	{TeamID: "1610612760", City: "Oklahoma City", Nickname: "Thunder", TeamName: "Oklahoma City Thunder", Abbr: "OKC"},
+	{TeamID: "1610612753", City: "Orlando", Nickname: "Magic", TeamName: "Orlando Magic", Abbr: "ORL"},
+	{TeamID: "1610612755", City: "Philadelphia", Nickname: "76ers", TeamName: "Philadelphia 76ers", Abbr: "PHI"},
+	{TeamID: "1610612756", City: "Phoenix", Nickname: "Suns", TeamName: "Phoenix Suns", Abbr: "PHX"},
+	{TeamID: "1610612757", City: "Portland", Nickname: "Trail Blazers", TeamName: "Portland Trail Blazers", Abbr: "POR"},
+	{TeamID: "1610612758", City: "Sacramento", Nickname: "Kings", TeamName: "Sacramento Kings", Abbr: "SAC"},
+	{TeamID: "1610612759", City: "San Antonio", Nickname: "Spurs", TeamName: "San Antonio Spurs", Abbr: "SAS"},
+	{TeamID: "1610612761", City: "Toronto", Nickname: "Raptors", TeamName: "Toronto Raptors", Abbr: "TOR"},
+	{TeamID: "1610612762", City: "Utah", Nickname: "Jazz", TeamName: "Utah Jazz", Abbr: "UTA"},
+	{TeamID: "1610612764", City: "Washington", Nickname: "Wizards", TeamName: "Washington Wizards", Abbr: "WAS"},
+}, nbaTeamAliases)
+
+// nbaTeamAliases maps other names and abbreviations in the data to a team's abbreviation
+var nbaTeamAliases = map[string]string{
+	"Los Angeles Clippers": "LAC",
+	"BRK":                  "BKN",
+	"CHO":                  "CHA",
+	"GS":                   "GSW",
+	"NO":                   "NOP",
+	"NY":                   "NYK",
+	"PHO":                  "PHX",
+	"SA":                   "SAS",
+	"Sixers":               "PHI",
+	"UTAH":                 "UTA",
+	"WSH":                  "WAS",
+}
+
+// NFL team IDs are the abbreviations used by nfl_roster_db.team_id and play-by-play data
+var nflTeams = newTeamRegistry([]models.Team{
+	{TeamID: "ARI", City: "Arizona", Nickname: "Cardinals", TeamName: "Arizona Cardinals", Abbr: "ARI"},
+	{TeamID: "ATL", City: "Atlanta", Nickname: "Falcons", TeamName: "Atlanta Falcons", Abbr: "ATL"},
+	{TeamID: "BAL", City: "Baltimore", Nickname: "Ravens", TeamName: "Baltimore Ravens", Abbr: "BAL"},
+	{TeamID: "BUF", City: "Buffalo", Nickname: "Bills", TeamName: "Buffalo Bills", Abbr: "BUF"},
+	{TeamID: "CAR", City: "Carolina", Nickname: "Panthers", TeamName: "Carolina Panthers", Abbr: "CAR"},
+	{TeamID: "CHI", City: "Chicago", Nickname: "Bears", TeamName: "Chicago Bears", Abbr: "CHI"},
+	{TeamID: "CIN", City: "Cincinnati", Nickname: "Bengals", TeamName: "Cincinnati Bengals", Abbr: "CIN"},
+	{TeamID: "CLE", City: "Cleveland", Nickname: "Browns", TeamName: "Cleveland Browns", Abbr: "CLE"},
+	{TeamID: "DAL", City: "Dallas", Nickname: "Cowboys", TeamName: "Dallas Cowboys", Abbr: "DAL"},
+	{TeamID: "DEN", City: "Denver", Nickname: "Broncos", TeamName: "Denver Broncos", Abbr: "DEN"},
+	{TeamID: "DET", City: "Detroit", Nickname: "Lions", TeamName: "Detroit Lions", Abbr: "DET"},
+	{TeamID: "GB", City: "Green Bay", Nickname: "Packers", TeamName: "Green Bay Packers", Abbr: "GB"},
+	{TeamID: "HOU", City: "Houston", Nickname: "Texans", TeamName: "Houston Texans", Abbr: "HOU"},
+	{TeamID: "IND", City: "Indianapolis", Nickname: "Colts", TeamName: "Indianapolis Colts", Abbr: "IND"},
+	{TeamID: "JAX", City: "Jacksonville", Nickname: "Jaguars", TeamName: "Jacksonville Jaguars", Abbr: "JAX"},
+	{TeamID: "KC", City: "Kansas City", Nickname: "Chiefs", TeamName: "Kansas City Chiefs", Abbr: "KC"},
+	{TeamID: "LV", City: "Las Vegas", Nickname: "Raiders", TeamName: "Las Vegas Raiders", Abbr: "LV"},
+	{TeamID: "LAC", City: "Los Angeles", Nickname: "Chargers", TeamName: "Los Angeles Chargers", Abbr: "LAC"},
+	{TeamID: "LA", City: "Los Angeles", Nickname: "Rams", TeamName: "Los Angeles Rams", Abbr: "LA"},
+	{TeamID: "MIA", City: "Miami", Nickname: "Dolphins", TeamName: "Miami Dolphins", Abbr: "MIA"},
+	{TeamID: "MIN", City: "Minnesota", Nickname: "Vikings", TeamName: "Minnesota Vikings", Abbr: "MIN"},
+	{TeamID: "NE", City: "New England", Nickname: "Patriots", TeamName: "New England Patriots", Abbr: "NE"},
+	{TeamID: "NO", City: "New Orleans", Nickname: "Saints", TeamName: "New Orleans Saints", Abbr: "NO"},
+	{TeamID: "NYG", City: "New York", Nickname: "Giants", TeamName: "New York Giants", Abbr: "NYG"},
+	{TeamID: "NYJ", City: "New York", Nickname: "Jets", TeamName: "New York Jets", Abbr: "NYJ"},
+	{TeamID: "PHI", City: "Philadelphia", Nickname: "Eagles", TeamName: "Philadelphia Eagles", Abbr: "PHI"},
+	{TeamID: "PIT", City: "Pittsburgh", Nickname: "Steelers", TeamName: "Pittsburgh Steelers", Abbr: "PIT"},
+	{TeamID: "SF", City: "San Francisco", Nickname: "49ers", TeamName: "San Francisco 49ers", Abbr: "SF"},
+	{TeamID: "SEA", City: "Seattle", Nickname: "Seahawks", TeamName: "Seattle Seahawks", Abbr: "SEA"},
+	{TeamID: "TB", City: "Tampa Bay", Nickname: "Buccaneers", TeamName: "Tampa Bay Buccaneers", Abbr: "TB"},
+	{TeamID: "TEN", City: "Tennessee", Nickname: "Titans", TeamName: "Tennessee Titans", Abbr: "TEN"},
+	{TeamID: "WAS", City: "Washington", Nickname: "Commanders", TeamName: "Washington Commanders", Abbr: "WAS"},
+}, nflTeamAliases)
+
+// nflTeamAliases maps other abbreviations in the data to a team's abbreviation
+var nflTeamAliases = map[string]string{
+	"LAR": "LA",
+	"JAC": "JAX",
+	"KAN": "KC",
+	"GNB": "GB",
+	"NWE": "NE",
+	"NOR": "NO",
+	"SFO": "SF",
+	"TAM": "TB",
+	"WSH": "WAS",
+	"LVR": "LV",
+	"OAK": "LV",
+}
+
+// NBATeams returns every NBA team in the registry
+func NBATeams() []models.Team {
+	return nbaTeams.all()
+}
+
+// NFLTeams returns every NFL team in the registry
+func NFLTeams() []models.Team {
+	return nflTeams.all()
+}
+
+// LookupNBATeam finds an NBA team by ID, city, nickname, full name or abbreviation, ignoring
+// case and punctuation. "Los Angeles" is the Lakers; the Clippers' city is "LA".
+func LookupNBATeam(s string) (models.Team, bool) {
+	return nbaTeams.lookup(s)
+}
+
+// LookupNFLTeam finds an NFL team by ID, city, nickname, full name or abbreviation, ignoring
+// case and punctuation. Cities shared by two teams ("New York") match neither.
+func LookupNFLTeam(s string) (models.Team, bool) {
+	return nflTeams.lookup(s)
+}
@@ -0,0 +1,66 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupNBATeam(t *testing.T) {
+	for _, s := range []string{"1610612738", "Boston", "celtics", "Boston Celtics", "BOS"} {
+		team, ok := LookupNBATeam(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, "1610612738", team.TeamID, s)
+	}
+
+	team, ok := LookupNBATeam("Los Angeles")
+	assert.True(t, ok)
+	assert.Equal(t, "LAL", team.Abbr)
+
+	team, ok = LookupNBATeam("Los Angeles Clippers")
+	assert.True(t, ok)
+	assert.Equal(t, "LAC", team.Abbr)
+
+	_, ok = LookupNBATeam("Seattle")
+	assert.False(t, ok)
+}
+
+func TestLookupNFLTeam(t *testing.T) {
+	team, ok := LookupNFLTeam("Kansas City Chiefs")
+	assert.True(t, ok)
+	assert.Equal(t, "KC", team.TeamID)
+
+	team, ok = LookupNFLTeam("LAR")
+	assert.True(t, ok)
+	assert.Equal(t, "Los Angeles Rams", team.TeamName)
+
+	_, ok = LookupNFLTeam("New York")
+	assert.False(t, ok)
+	_, ok = LookupNFLTeam("Los Angeles")
+	assert.False(t, ok)
+}
+
+func TestTeamRegistriesComplete(t *testing.T) {
+	assert.Len(t, NBATeams(), 30)
+	assert.Len(t, NFLTeams(), 32)
+	for _, team := range append(NBATeams(), NFLTeams()...) {
+		assert.NotEmpty(t, team.TeamID)
+		assert.NotEmpty(t, team.TeamName)
+		assert.NotEmpty(t, team.City)
+		assert.NotEmpty(t, team.Nickname)
+		assert.NotEmpty(t, team.Abbr)
+	}
+}
+
+func TestTeamAliasesResolve(t *testing.T) {
+	for alias, abbr := range nbaTeamAliases {
+		team, ok := LookupNBATeam(alias)
+		assert.True(t, ok, alias)
+		assert.Equal(t, abbr, team.Abbr, alias)
+	}
+	for alias, abbr := range nflTeamAliases {
+		team, ok := LookupNFLTeam(alias)
+		assert.True(t, ok, alias)
+		assert.Equal(t, abbr, team.Abbr, alias)
+	}
+}
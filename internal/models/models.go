@@ -13,11 +13,12 @@ type Player struct {
 	Status     string `json:"status"`
 }
 
-// Team represents an NBA team
+// Team represents an NBA or NFL team. TeamName is the full name, e.g. "Boston Celtics".
 type Team struct {
 	TeamID   string `json:"team_id"`
 	TeamName string `json:"team_name"`
 	City     string `json:"city"`
+	Nickname string `json:"nickname"`
 	Abbr     string `json:"abbr"`
 }
 
@@ -44,16 +45,28 @@ type TeamGameLog struct {
 
 // Game represents a live game
 type Game struct {
-	GameID   string `json:"game_id"`
-	HomeCity string `json:"home_city"`
-	HomeTeam string `json:"home_team"`
-	AwayCity string `json:"away_city"`
-	AwayTeam string `json:"away_team"`
+	GameID     string          `json:"game_id"`
+	HomeCity   string          `json:"home_city"`
+	HomeTeam   string          `json:"home_team"`
+	AwayCity   string          `json:"away_city"`
+	AwayTeam   string          `json:"away_team"`
+	GameDate   string          `json:"game_date"`
+	StatusCode int             `json:"-"`
+	Status     string          `json:"status"`
+	StatusText string          `json:"status_text"`
+	Period     int             `json:"period"`
+	GameClock  string          `json:"game_clock"`
+	StartTime  *time.Time      `json:"start_time"`
+	HomeScore  int             `json:"home_score"`
+	AwayScore  int             `json:"away_score"`
+	HomeOdds   []MoneylineOdds `json:"home_moneyline"`
+	AwayOdds   []MoneylineOdds `json:"away_moneyline"`
 }
 
 // TeamDefenseStats represents team defensive statistics
 type NBATeamDefenseStats struct {
 	TeamName        string  `json:"team_name"`
+	Season          string  `json:"season"`
 	OppFgaRank      int     `json:"opp_fga_rank"`
 	OppFga          float64 `json:"opp_fga"`
 	OppFgPctRank    int     `json:"opp_fg_pct_rank"`
@@ -86,6 +99,7 @@ type NBATeamDefenseStats struct {
 
 type NBATeamOffenseStats struct {
 	TeamName      string  `json:"team_name"`
+	Season        string  `json:"season"`
 	OffRatingRank int     `json:"off_rating_rank"`
 	OffRating     float64 `json:"off_rating"`
 	RebPctRank    int     `json:"reb_pct_rank"`
@@ -104,9 +118,26 @@ type NBATeamOffenseStats struct {
 	OrebPct       float64 `json:"oreb_pct"`
 }
 
+// NBADefenseVsPosition represents per-game production a team allows to guards (G), forwards (F)
+// or centers (C). Ranks are league-wide within the position; rank 1 allows the most.
+type NBADefenseVsPosition struct {
+	TeamName              string  `json:"team_name"`
+	Position              string  `json:"position"`
+	Games                 int     `json:"games"`
+	Points                float64 `json:"points"`
+	PointsRank            int     `json:"points_rank"`
+	Rebounds              float64 `json:"rebounds"`
+	ReboundsRank          int     `json:"rebounds_rank"`
+	Assists               float64 `json:"assists"`
+	AssistsRank           int     `json:"assists_rank"`
+	ThreePointersMade     float64 `json:"threePointersMade"`
+	ThreePointersMadeRank int     `json:"threePointersMade_rank"`
+}
+
 // PlayerShootingSplits represents player shooting statistics
 type NBAPlayerShootingSplits struct {
 	PlayerName    string  `json:"player_name"`
+	Season        string  `json:"season"`
 	Fg2a          float64 `json:"fg2a"`
 	Fg2m          float64 `json:"fg2m"`
 	Fg2Pct        float64 `json:"fg2_pct"`
@@ -124,13 +155,14 @@ type NBAPlayerShootingSplits struct {
 // PlayerHeadlineStats represents player headline statistics
 type NBAPlayerHeadlineStats struct {
 	PlayerName string  `json:"player_name"`
+	Season     string  `json:"season"`
 	Points     float64 `json:"points"`
 	Assists    float64 `json:"assists"`
 	Rebounds   float64 `json:"rebounds"`
 }
 
 type NBAPlayerShotChartStats struct {
-	GameDate     time.Time `json:game_date`
+	GameDate     time.Time `json:"GameDate"`
 	LocX         int       `json:"loc_x"`
 	LocY         int       `json:"loc_y"`
 	ShotMadeFlag int       `json:"shot_made_flag"`
@@ -161,22 +193,37 @@ type OpponentZonesResponse struct {
 	Zones  map[string]ZoneValue `json:"zones"`
 }
 
-// PlayerModel represents the input for points prediction
+// PlayerModel represents the input for points prediction. Both fields are optional.
 type PlayerModel struct {
 	OppCity string  `json:"opp_city"`
 	Minutes float64 `json:"minutes"`
 }
 
-// PoissonDist represents Poisson distribution input
+// PoissonDist represents over/under distribution input. Distribution defaults to poisson; the
+// others need PlayerID (an NBA player ID or NFL gamelog name) to estimate spread from the
+// player's game log in Market (default player_points for NBA), limited to the last Games games
+// when set. PredictedPoints defaults to the game log's mean.
 type PoissonDist struct {
 	PredictedPoints float64 `json:"predictedPoints"`
 	BookLine        float64 `json:"bookLine"`
+	Distribution    string  `json:"distribution"`
+	PlayerID        string  `json:"player_id"`
+	Market          string  `json:"market"`
+	Games           int     `json:"games"`
 }
 
-// PoissonResponse represents Poisson distribution response
+// PoissonResponse represents over/under distribution response. Less and Greater repeat Under and
+// Over for older clients.
 type PoissonResponse struct {
-	Less    float64 `json:"less"`
-	Greater float64 `json:"greater"`
+	Distribution string  `json:"distribution"`
+	Mean         float64 `json:"mean"`
+	Line         float64 `json:"line"`
+	Games        int     `json:"games"`
+	Under        float64 `json:"under"`
+	Push         float64 `json:"push"`
+	Over         float64 `json:"over"`
+	Less         float64 `json:"less"`
+	Greater      float64 `json:"greater"`
 }
 
 // RegisterItem represents user registration input
@@ -212,6 +259,7 @@ type NFLPlayer struct {
 }
 
 type NFLPlayerRushingStats struct {
+	Season                    int     `json:"season"`
 	AvgGain                   float64 `json:"avgGain"`
 	LongRushing               int     `json:"longRushing"`
 	NetTotalYards             int     `json:"netTotalYards"`
@@ -242,6 +290,7 @@ type NFLPlayerRushingStats struct {
 }
 
 type NFLPlayerReceivingStats struct {
+	Season                    int     `json:"season"`
 	AvgGain                   float64 `json:"avgGain"`
 	LongReception             int     `json:"longReception"`
 	NetTotalYards             int     `json:"netTotalYards"`
@@ -273,6 +322,7 @@ type NFLPlayerReceivingStats struct {
 }
 
 type NFLPlayerPassingStats struct {
+	Season                 int     `json:"season"`
 	AvgGain                float64 `json:"avgGain"`
 	CompletionPct          float64 `json:"completionPct"`
 	Completions            int     `json:"completions"`
@@ -290,16 +340,27 @@ type NFLPlayerPassingStats struct {
 }
 
 type NFLEvent struct {
-	EventID   string `json:"event_id"`
-	EventDate string `json:"event_date"`
-	EventWeek int    `json:"event_week"`
+	EventID     string     `json:"event_id"`
+	EventDate   string     `json:"event_date"`
+	EventWeek   int        `json:"event_week"`
+	Season      int        `json:"season"`
+	HomeTeam    string     `json:"home_team"`
+	AwayTeam    string     `json:"away_team"`
+	Kickoff     *time.Time `json:"kickoff"`
+	Status      string     `json:"status"`
+	HomeScore   *int       `json:"home_score"`
+	AwayScore   *int       `json:"away_score"`
+	PropMarkets []string   `json:"prop_markets"`
 }
 
 type NFLPlayerRushingReceivingGamelogStats struct {
 	GameID              string    `json:"game_id"`
 	PlayerName          string    `json:"player_name"`
+	Season              int       `json:"season"`
 	GameDate            time.Time `json:"game_date"`
 	GameWeek            int       `json:"game_week"`
+	Opponent            string    `json:"opponent"`
+	HomeAway            string    `json:"home_away"`
 	RushingAttempts     int       `json:"rushingAttempts"`
 	YardsPerRushAttempt float64   `json:"yardsPerRushAttempt"`
 	RushingYards        int       `json:"rushingYards"`
@@ -320,8 +381,11 @@ type NFLPlayerRushingReceivingGamelogStats struct {
 type NFLPlayerPassingGamelogStats struct {
 	GameID              string    `json:"game_id"`
 	PlayerName          string    `json:"player_name"`
+	Season              int       `json:"season"`
 	GameDate            time.Time `json:"game_date"`
 	GameWeek            int       `json:"game_week"`
+	Opponent            string    `json:"opponent"`
+	HomeAway            string    `json:"home_away"`
 	RushingAttempts     int       `json:"rushingAttempts"`
 	YardsPerRushAttempt float64   `json:"yardsPerRushAttempt"`
 	RushingYards        int       `json:"rushingYards"`
@@ -338,8 +402,52 @@ type NFLPlayerPassingGamelogStats struct {
 	OffenseSnapPct      float64   `json:"offenseSnapPct"`
 }
 
-type NFLPlayerGamelogCollection[T any] struct {
-	Games []T `json:"games"`
+// NFLRushingReceivingTotals sums a season's rushing/receiving gamelog rows
+type NFLRushingReceivingTotals struct {
+	Season              int     `json:"season"`
+	Games               int     `json:"games"`
+	RushingAttempts     int     `json:"rushingAttempts"`
+	YardsPerRushAttempt float64 `json:"yardsPerRushAttempt"`
+	RushingYards        int     `json:"rushingYards"`
+	RushingTouchdowns   int     `json:"rushingTouchdowns"`
+	LongRushing         int     `json:"longRushing"`
+	Receptions          int     `json:"receptions"`
+	ReceivingTargets    int     `json:"receivingTargets"`
+	ReceivingYards      int     `json:"receivingYards"`
+	YardsPerReception   float64 `json:"yardsPerReception"`
+	ReceivingTouchdowns int     `json:"receivingTouchdowns"`
+	LongReception       int     `json:"longReception"`
+	Fumbles             int     `json:"fumbles"`
+	FumblesLost         int     `json:"fumblesLost"`
+	OffenseSnaps        int     `json:"offenseSnaps"`
+	OffenseSnapPct      float64 `json:"offenseSnapPct"`
+}
+
+// NFLPassingTotals sums a season's passing gamelog rows. QBRating and OffenseSnapPct are per-game averages.
+type NFLPassingTotals struct {
+	Season              int     `json:"season"`
+	Games               int     `json:"games"`
+	RushingAttempts     int     `json:"rushingAttempts"`
+	YardsPerRushAttempt float64 `json:"yardsPerRushAttempt"`
+	RushingYards        int     `json:"rushingYards"`
+	RushingTouchdowns   int     `json:"rushingTouchdowns"`
+	LongRushing         int     `json:"longRushing"`
+	PassingAttempts     int     `json:"passingAttempts"`
+	PassingCompletions  int     `json:"passingCompletions"`
+	CompletionPct       float64 `json:"completionPct"`
+	PassingYards        int     `json:"passingYards"`
+	PassingTouchdowns   int     `json:"passingTouchdowns"`
+	Interceptions       int     `json:"interceptions"`
+	QBRating            float64 `json:"QBRating"`
+	YardsPerPassAttempt float64 `json:"yardsPerPassAttempt"`
+	OffenseSnaps        int     `json:"offenseSnaps"`
+	OffenseSnapPct      float64 `json:"offenseSnapPct"`
+}
+
+// NFLPlayerGamelogCollection holds a player's gamelog with one totals entry per season, oldest first
+type NFLPlayerGamelogCollection[T any, S any] struct {
+	Games  []T `json:"games"`
+	Totals []S `json:"totals"`
 }
 
 type NFLTeamDefenseStats struct {
@@ -432,6 +540,134 @@ type NFLTeamOffenseStats struct {
 	RushStuffRateRank       int     `json:"rush_stuff_rate_rank"`
 }
 
+// NFLTeamDefenseStatsV2 is NFLTeamDefenseStats with every value numeric and every rank an int.
+// A rank is nil when the source has none for the team. Percentages are returned as plain
+// numbers (e.g. "7.5%" becomes 7.5).
+type NFLTeamDefenseStatsV2 struct {
+	TeamName                        string  `json:"team_name"`
+	SacksRate                       float64 `json:"sacks_rate"`
+	SacksRateRank                   *int    `json:"sacks_rate_rank"`
+	EPAperPlayAllowed               float64 `json:"epa_per_play_allowed"`
+	EPAperPlayAllowedRank           *int    `json:"epa_per_play_allowed_rank"`
+	SuccessRateAllowed              float64 `json:"success_rate_allowed"`
+	SuccessRateAllowedRank          *int    `json:"success_rate_allowed_rank"`
+	RushEPAAllowed                  float64 `json:"rush_epa_allowed"`
+	RushEPAAllowedRank              *int    `json:"rush_epa_allowed_rank"`
+	RushSuccessRateAllowed          float64 `json:"rush_success_rate_allowed"`
+	RushSuccessRateAllowedRank      *int    `json:"rush_success_rate_allowed_rank"`
+	DropbackEPAAllowed              float64 `json:"dropback_epa_allowed"`
+	DropbackEPAAllowedRank          *int    `json:"dropback_epa_allowed_rank"`
+	DropbackSuccessRateAllowed      float64 `json:"dropback_success_rate_allowed"`
+	DropbackSuccessRateAllowedRank  *int    `json:"dropback_success_rate_allowed_rank"`
+	ExplosivePlayRateAllowed        float64 `json:"explosive_play_rate_allowed"`
+	ExplosivePlayRateAllowedRank    *int    `json:"explosive_play_rate_allowed_rank"`
+	PressureRate                    float64 `json:"pressure_rate"`
+	PressureRateRank                *int    `json:"pressure_rate_rank"`
+	BlitzRate                       float64 `json:"blitz_rate"`
+	BlitzRateRank                   *int    `json:"blitz_rate_rank"`
+	ManRate                         float64 `json:"man_rate"`
+	ManRateRank                     *int    `json:"man_rate_rank"`
+	ZoneRate                        float64 `json:"zone_rate"`
+	ZoneRateRank                    *int    `json:"zone_rate_rank"`
+	RushStuffRate                   float64 `json:"rush_stuff_rate"`
+	RushStuffRateRank               *int    `json:"rush_stuff_rate_rank"`
+	YardsBeforeContactPerRbRush     float64 `json:"yards_before_contact_per_rb_rush"`
+	YardsBeforeContactPerRbRushRank *int    `json:"yards_before_contact_per_rb_rush_rank"`
+	DownConversionRateAllowed       float64 `json:"down_conversion_rate_allowed"`
+	DownConversionRateAllowedRank   *int    `json:"down_conversion_rate_allowed_rank"`
+	YardsPerPlayAllowed             float64 `json:"yards_per_play_allowed"`
+	YardsPerPlayAllowedRank         *int    `json:"yards_per_play_allowed_rank"`
+	Adot                            float64 `json:"adot"`
+	AdotRank                        *int    `json:"adot_rank"`
+	ScrambleRate                    float64 `json:"scramble_rate"`
+	ScrambleRateRank                *int    `json:"scramble_rate_rank"`
+	IntRate                         float64 `json:"int_rate"`
+	IntRateRank                     *int    `json:"int_rate_rank"`
+	YardsAllowedWR                  float64 `json:"yds_allowed_wr"`
+	YardsAllowedWRRank              *int    `json:"yds_allowed_wr_rank"`
+	YardsAllowedTE                  float64 `json:"yds_allowed_te"`
+	YardsAllowedTERank              *int    `json:"yds_allowed_te_rank"`
+	YardsAllowedRB                  float64 `json:"yds_allowed_rb"`
+	YardsAllowedRBRank              *int    `json:"yds_allowed_rb_rank"`
+}
+
+// NFLTeamOffenseStatsV2 is NFLTeamOffenseStats with every value numeric and every rank an int,
+// or nil when the source has none for the team. SackRate is the percentage of dropbacks sacked.
+type NFLTeamOffenseStatsV2 struct {
+	TeamName                string  `json:"team_name"`
+	EPAperPlay              float64 `json:"epa_per_play"`
+	EPAperPlayRank          *int    `json:"epa_per_play_rank"`
+	DropbackEPA             float64 `json:"dropback_epa"`
+	DropbackEPARank         *int    `json:"dropback_epa_rank"`
+	RushEPA                 float64 `json:"rush_epa"`
+	RushEPARank             *int    `json:"rush_epa_rank"`
+	SuccessRate             float64 `json:"success_rate"`
+	SuccessRateRank         *int    `json:"success_rate_rank"`
+	RushSuccessRate         float64 `json:"rush_success_rate"`
+	RushSuccessRateRank     *int    `json:"rush_success_rate_rank"`
+	DropbackSuccessRate     float64 `json:"dropback_success_rate"`
+	DropbackSuccessRateRank *int    `json:"dropback_success_rate_rank"`
+	PassingYardsPerGame     float64 `json:"passingYardsPerGame"`
+	PassingYardsPerGameRank *int    `json:"passingYardsPerGame_rank"`
+	YardsPerCompletion      float64 `json:"yardsPerCompletion"`
+	YardsPerCompletionRank  *int    `json:"yardsPerCompletion_rank"`
+	SackRate                float64 `json:"sack_rate"`
+	SackRateRank            *int    `json:"sack_rate_rank"`
+	RushingAttempts         float64 `json:"rushingAttempts"`
+	RushingAttemptsRank     *int    `json:"rushingAttempts_rank"`
+	YardsPerRushAttempt     float64 `json:"yardsPerRushAttempt"`
+	YardsPerRushAttemptRank *int    `json:"yardsPerRushAttempt_rank"`
+	PassingAttempts         float64 `json:"passingAttempts"`
+	PassingAttemptsRank     *int    `json:"passingAttempts_rank"`
+	Adot                    float64 `json:"adot"`
+	AdotRank                *int    `json:"adot_rank"`
+	ScrambleRate            float64 `json:"scramble_rate"`
+	ScrambleRateRank        *int    `json:"scramble_rate_rank"`
+	IntRate                 float64 `json:"int_rate"`
+	IntRateRank             *int    `json:"int_rate_rank"`
+	TimeToThrow             float64 `json:"time_to_throw"`
+	TimeToThrowRank         *int    `json:"time_to_throw_rank"`
+	PressureRateAllowed     float64 `json:"pressure_rate_allowed"`
+	PressureRateAllowedRank *int    `json:"pressure_rate_allowed_rank"`
+	ExplosivePlayRate       float64 `json:"explosive_play_rate"`
+	ExplosivePlayRateRank   *int    `json:"explosive_play_rate_rank"`
+	RushStuffRate           float64 `json:"rush_stuff_rate"`
+	RushStuffRateRank       *int    `json:"rush_stuff_rate_rank"`
+}
+
+// NFLDefenseVsPosition represents per-game production a defense allows to one position.
+// Ranks are league-wide within the position; rank 1 allows the most.
+type NFLDefenseVsPosition struct {
+	TeamName           string                    `json:"team_name"`
+	Position           string                    `json:"position"`
+	Games              int                       `json:"games"`
+	RushingYards       float64                   `json:"rushing_yards"`
+	RushingYardsRank   int                       `json:"rushing_yards_rank"`
+	ReceivingYards     float64                   `json:"receiving_yards"`
+	ReceivingYardsRank int                       `json:"receiving_yards_rank"`
+	Receptions         float64                   `json:"receptions"`
+	ReceptionsRank     int                       `json:"receptions_rank"`
+	Targets            float64                   `json:"targets"`
+	TargetsRank        int                       `json:"targets_rank"`
+	Touchdowns         float64                   `json:"touchdowns"`
+	TouchdownsRank     int                       `json:"touchdowns_rank"`
+	FantasyPoints      float64                   `json:"fantasy_points"`
+	FantasyPointsRank  int                       `json:"fantasy_points_rank"`
+	Last4              NFLDefenseVsPositionTrend `json:"last_4"`
+}
+
+// NFLDefenseVsPositionTrend represents the same per-game allowances over a defense's last 4 games
+type NFLDefenseVsPositionTrend struct {
+	Games              int     `json:"games"`
+	RushingYards       float64 `json:"rushing_yards"`
+	ReceivingYards     float64 `json:"receiving_yards"`
+	Receptions         float64 `json:"receptions"`
+	Targets            float64 `json:"targets"`
+	Touchdowns         float64 `json:"touchdowns"`
+	FantasyPoints      float64 `json:"fantasy_points"`
+	FantasyPointsDelta float64 `json:"fantasy_points_delta"`
+}
+
 type NFLPassingPBPStats struct {
 	Week         int    `json:"week"`
 	Opponent     string `json:"opponent"`
@@ -442,6 +678,69 @@ type NFLPassingPBPStats struct {
 	PassLength   string `json:"pass_length"`
 }
 
+// NFLReceiverUsage represents a pass catcher's usage for one week, or a season when Week is 0.
+// Team totals cover the weeks the player appeared in.
+type NFLReceiverUsage struct {
+	PlayerName         string  `json:"player_name"`
+	TeamName           string  `json:"team_name"`
+	Week               int     `json:"week,omitempty"`
+	Games              int     `json:"games,omitempty"`
+	Targets            int     `json:"targets"`
+	Receptions         int     `json:"receptions"`
+	ReceivingYards     int     `json:"receiving_yards"`
+	OffenseSnaps       int     `json:"offense_snaps"`
+	AirYards           float64 `json:"air_yards"`
+	TeamTargets        int     `json:"team_targets"`
+	TeamAirYards       float64 `json:"team_air_yards"`
+	TargetShare        float64 `json:"target_share"`
+	AirYardsShare      float64 `json:"air_yards_share"`
+	WOPR               float64 `json:"wopr"`
+	ADOT               float64 `json:"adot"`
+	YardsPerRouteProxy float64 `json:"yards_per_route_proxy"`
+}
+
+// NFLSnapCountWeek represents a player's offensive snaps for one week with the change since
+// their previous game and 3-game rolling averages. PriorWeek is 0 for a player's first game.
+type NFLSnapCountWeek struct {
+	PlayerName     string  `json:"player_name"`
+	TeamName       string  `json:"team_name"`
+	Position       string  `json:"position"`
+	Week           int     `json:"week"`
+	OffenseSnaps   int     `json:"offenseSnaps"`
+	OffenseSnapPct float64 `json:"offenseSnapPct"`
+	PriorWeek      int     `json:"prior_week,omitempty"`
+	SnapsChange    int     `json:"snaps_change"`
+	SnapPctChange  float64 `json:"snap_pct_change"`
+	RollingSnaps   float64 `json:"rolling_snaps"`
+	RollingSnapPct float64 `json:"rolling_snap_pct"`
+}
+
+// NFLPlayerSnapTrend groups a player's weekly snap counts
+type NFLPlayerSnapTrend struct {
+	PlayerName string             `json:"player_name"`
+	Position   string             `json:"position"`
+	Weeks      []NFLSnapCountWeek `json:"weeks"`
+}
+
+// NFLPassingGridCell aggregates pass attempts for one pass location and length
+type NFLPassingGridCell struct {
+	PassLocation     string  `json:"pass_location"`
+	PassLength       string  `json:"pass_length"`
+	Attempts         int     `json:"attempts"`
+	Completions      int     `json:"completions"`
+	Interceptions    int     `json:"interceptions"`
+	CompletionPct    float64 `json:"completion_pct"`
+	InterceptionRate float64 `json:"interception_rate"`
+	AvgAirYards      float64 `json:"avg_air_yards"`
+}
+
+// NFLPassingGrid is the location x length grid of pass attempts, for one week or a full season
+type NFLPassingGrid struct {
+	Week     int                  `json:"week,omitempty"`
+	Attempts int                  `json:"attempts"`
+	Cells    []NFLPassingGridCell `json:"cells"`
+}
+
 type Odds struct {
 	Name      string  `json:"name"`
 	Market    string  `json:"market"`
@@ -454,5 +753,341 @@ type Odds struct {
 type MoneylineOdds struct {
 	Team      string `json:"team"`
 	Sportbook string `json:"sportbook"`
-	Price     string `json:price`
+	Price     string `json:"Price"`
+}
+
+// NFLSeasonDelta is the change in a player's key per-game rates from the previous season
+type NFLSeasonDelta struct {
+	Season         int                `json:"season"`
+	PreviousSeason int                `json:"previous_season"`
+	Deltas         map[string]float64 `json:"deltas"`
+}
+
+// SearchResult is a player or team matched by /search, ranked by Score (0-1)
+type SearchResult struct {
+	Sport    string  `json:"sport"`
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Team     string  `json:"team"`
+	Position string  `json:"position"`
+	Score    float64 `json:"score"`
+}
+
+// UnmatchedNames lists the player names from one source that don't resolve to a rostered player
+type UnmatchedNames struct {
+	Source    string   `json:"source"`
+	Total     int      `json:"total"`
+	Count     int      `json:"count"`
+	Unmatched []string `json:"unmatched"`
+}
+
+// PlayerAlias maps a source's spelling of a player's name to the player's ID
+type PlayerAlias struct {
+	Source   string `json:"source" binding:"required"`
+	Alias    string `json:"alias" binding:"required"`
+	PlayerID string `json:"player_id" binding:"required"`
+}
+
+// NBAInjuryStatus is one player's row from an injury report ingestion
+type NBAInjuryStatus struct {
+	PlayerID     string `json:"player_id"`
+	PlayerName   string `json:"player_name"`
+	Team         string `json:"team"`
+	Status       string `json:"status"`
+	IngestedDate string `json:"ingested_date"`
+}
+
+// NBAInjuryStatusEntry is a status as of one injury report ingestion
+type NBAInjuryStatusEntry struct {
+	Status       string `json:"status"`
+	IngestedDate string `json:"ingested_date"`
+}
+
+// NBAInjuryHistory is a player's status across injury report ingestions, oldest first
+type NBAInjuryHistory struct {
+	PlayerID   string                 `json:"player_id"`
+	PlayerName string                 `json:"player_name"`
+	Team       string                 `json:"team"`
+	History    []NBAInjuryStatusEntry `json:"history"`
+}
+
+// NBAInjuryChange is a status that differs between a team's two most recent injury reports.
+// An empty PreviousStatus means the player was added to the report, an empty Status that they
+// were removed from it.
+type NBAInjuryChange struct {
+	PlayerID             string `json:"player_id"`
+	PlayerName           string `json:"player_name"`
+	Team                 string `json:"team"`
+	PreviousStatus       string `json:"previous_status"`
+	Status               string `json:"status"`
+	PreviousIngestedDate string `json:"previous_ingested_date"`
+	IngestedDate         string `json:"ingested_date"`
+}
+
+// NBAPlayerGameLine is one player's box score line in one game
+type NBAPlayerGameLine struct {
+	GameID     string  `json:"game_id"`
+	PlayerID   string  `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	Points     float64 `json:"points"`
+	Assists    float64 `json:"assists"`
+	Rebounds   float64 `json:"rebounds"`
+	Minutes    float64 `json:"minutes"`
+}
+
+// NBASplitAverages are per-game averages over Games games
+type NBASplitAverages struct {
+	Games    int     `json:"games"`
+	Points   float64 `json:"points"`
+	Assists  float64 `json:"assists"`
+	Rebounds float64 `json:"rebounds"`
+	Minutes  float64 `json:"minutes"`
+}
+
+// NBAWithWithoutSplit compares a player's averages in games where a set of teammates all played
+// (With) against games where none of them played (Without)
+type NBAWithWithoutSplit struct {
+	PlayerID   string           `json:"player_id"`
+	PlayerName string           `json:"player_name"`
+	With       NBASplitAverages `json:"with"`
+	Without    NBASplitAverages `json:"without"`
+}
+
+// OddsChange is a prop line opening, moving or being pulled between two odds snapshots.
+// Previous values are zero for an open and current values are zero for a close.
+type OddsChange struct {
+	ID            int64     `json:"id"`
+	Type          string    `json:"type"`
+	Sport         string    `json:"sport"`
+	Player        string    `json:"player"`
+	Market        string    `json:"market"`
+	Sportbook     string    `json:"sportbook"`
+	Line          float32   `json:"line"`
+	Over          int       `json:"over"`
+	Under         int       `json:"under"`
+	PreviousLine  float32   `json:"previous_line"`
+	PreviousOver  int       `json:"previous_over"`
+	PreviousUnder int       `json:"previous_under"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+// ScoreboardUpdate is one game's changes on the live scoreboard feed. Changes lists what moved
+// since the last poll: new, score, status or period.
+type ScoreboardUpdate struct {
+	Type    string   `json:"type"`
+	Changes []string `json:"changes"`
+	Game    Game     `json:"game"`
+}
+
+// AlertRule asks for a webhook when a prop line moves by at least Threshold in Direction.
+// Empty Sport, Player, Market or Sportbook match any. ThresholdUnit is "points" for the line or
+// "cents" for the over price; Direction is "up", "down" or "any".
+type AlertRule struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"`
+	Sport         string    `json:"sport"`
+	Player        string    `json:"player"`
+	Market        string    `json:"market"`
+	Sportbook     string    `json:"sportbook"`
+	Threshold     float64   `json:"threshold"`
+	ThresholdUnit string    `json:"threshold_unit"`
+	Direction     string    `json:"direction"`
+	WebhookURL    string    `json:"webhook_url"`
+	Secret        string    `json:"secret,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AlertDelivery records one webhook delivery for a rule, after any retries
+type AlertDelivery struct {
+	ID          string    `json:"id"`
+	RuleID      string    `json:"rule_id"`
+	UserID      string    `json:"user_id"`
+	ChangeID    int64     `json:"change_id"`
+	WebhookURL  string    `json:"webhook_url"`
+	Attempts    int       `json:"attempts"`
+	StatusCode  int       `json:"status_code"`
+	Success     bool      `json:"success"`
+	Error       string    `json:"error"`
+	Payload     string    `json:"payload"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+// Bet is a user's logged prop bet. Side is "over" or "under" and Price is American odds. GameDate
+// is the date of the game the bet is on, defaulting to the Eastern date PlacedAt falls on. Status
+// is "pending" until the player's game on GameDate is in the gamelogs, then "won", "lost" or
+// "push", or "void" when the gamelogs move past GameDate without the player. Closing values come from the last odds snapshot before the game started and
+// the CLV fields compare the bet against them: positive values beat the close.
+type Bet struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	Sport        string     `json:"sport"`
+	Player       string     `json:"player"`
+	Market       string     `json:"market"`
+	Side         string     `json:"side"`
+	Line         float64    `json:"line"`
+	Price        int        `json:"price"`
+	Sportbook    string     `json:"sportbook"`
+	Stake        float64    `json:"stake"`
+	PlacedAt     time.Time  `json:"placed_at"`
+	GameDate     string     `json:"game_date"`
+	Status       string     `json:"status"`
+	GameID       string     `json:"game_id"`
+	Actual       *float64   `json:"actual"`
+	Profit       float64    `json:"profit"`
+	ClosingLine  *float64   `json:"closing_line"`
+	ClosingPrice *int       `json:"closing_price"`
+	CLVLine      *float64   `json:"clv_line"`
+	CLVProb      *float64   `json:"clv_prob"`
+	GradedAt     *time.Time `json:"graded_at"`
+}
+
+// BetOutcome is the stat a bet settles on from the player's game on the bet's game date, and when
+// that game started (zero when no start time is known)
+type BetOutcome struct {
+	GameID string
+	Value  float64
+	Start  time.Time
+}
+
+// BetRecord summarizes a group of bets. Staked, Profit and ROI cover settled bets, leaving out
+// voided ones; the CLV averages cover bets with a closing snapshot.
+type BetRecord struct {
+	Bets       int     `json:"bets"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	Pushes     int     `json:"pushes"`
+	Voids      int     `json:"voids"`
+	Pending    int     `json:"pending"`
+	Staked     float64 `json:"staked"`
+	Profit     float64 `json:"profit"`
+	ROI        float64 `json:"roi"`
+	CLVBets    int     `json:"clv_bets"`
+	AvgCLVLine float64 `json:"avg_clv_line"`
+	AvgCLVProb float64 `json:"avg_clv_prob"`
+	BeatClose  float64 `json:"beat_close_rate"`
+}
+
+// BetSummary is a user's betting record overall and by market and sportsbook
+type BetSummary struct {
+	Overall  BetRecord            `json:"overall"`
+	ByMarket map[string]BetRecord `json:"by_market"`
+	ByBook   map[string]BetRecord `json:"by_book"`
+}
+
+// CalibrationBin compares the over probabilities predicted in a range with how often the over hit
+type CalibrationBin struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Count         int     `json:"count"`
+	MeanPredicted float64 `json:"mean_predicted"`
+	ObservedRate  float64 `json:"observed_rate"`
+}
+
+// BacktestReport scores a projection model on one market over a date range. MAE and Bias cover
+// every projected game; the betting fields cover games with a prop line, betting one unit on
+// whichever side the model gives more than the price's implied probability.
+type BacktestReport struct {
+	Market       string           `json:"market"`
+	Model        string           `json:"model"`
+	Distribution string           `json:"distribution"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Games        int              `json:"games"`
+	MAE          float64          `json:"mae"`
+	Bias         float64          `json:"bias"`
+	LinesMatched int              `json:"lines_matched"`
+	Bets         int              `json:"bets"`
+	Wins         int              `json:"wins"`
+	Losses       int              `json:"losses"`
+	Pushes       int              `json:"pushes"`
+	HitRate      float64          `json:"hit_rate"`
+	Units        float64          `json:"units"`
+	ROI          float64          `json:"roi"`
+	Calibration  []CalibrationBin `json:"calibration"`
+}
+
+// NBAPlayerGameStat is one player's value for a market's stat in one game
+type NBAPlayerGameStat struct {
+	PlayerID string  `json:"player_id"`
+	GameID   string  `json:"game_id"`
+	GameDate string  `json:"game_date"`
+	Opponent string  `json:"opponent"`
+	Value    float64 `json:"value"`
+	Minutes  float64 `json:"minutes"`
+	// StartTime is the game's tip-off, zero when the scoreboard doesn't have it
+	StartTime time.Time `json:"start_time"`
+}
+
+// PropLine is a prop line as it stood just before a tip-off on a date
+type PropLine struct {
+	Player    string    `json:"player"`
+	PlayerID  string    `json:"player_id"`
+	Date      string    `json:"date"`
+	StartTime time.Time `json:"start_time"`
+	Sportbook string    `json:"sportbook"`
+	Line      float64   `json:"line"`
+	Over      int       `json:"over"`
+	Under     int       `json:"under"`
+}
+
+// ProjectionModel describes a registered projection model. Inputs lists the request context the
+// model uses beyond the player's game log ("minutes", "opponent").
+type ProjectionModel struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	MinGames    int            `json:"min_games"`
+	Inputs      []string       `json:"inputs"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+// StatGame is a player's gamelog columns in one game
+type StatGame struct {
+	GameID   string             `json:"game_id"`
+	GameDate string             `json:"game_date"`
+	Stats    map[string]float64 `json:"stats"`
+}
+
+// SimulationLeg is one prop in a simulated combination
+type SimulationLeg struct {
+	Player string  `json:"player"`
+	Market string  `json:"market"`
+	Side   string  `json:"side"`
+	Line   float64 `json:"line"`
+}
+
+// SimulationRequest asks for the joint outcome of a set of legs. Games caps each player's game
+// log at their most recent games; Seed makes the run reproducible and is generated when left out.
+type SimulationRequest struct {
+	Sport      string          `json:"sport"`
+	Legs       []SimulationLeg `json:"legs"`
+	Iterations int             `json:"iterations"`
+	Seed       *uint64         `json:"seed"`
+	Games      int             `json:"games"`
+}
+
+// SimulationLegResult is how often a leg's stat landed over, under and on its line, and how often
+// the leg's side won
+type SimulationLegResult struct {
+	SimulationLeg
+	Games int     `json:"games"`
+	Mean  float64 `json:"mean"`
+	Over  float64 `json:"over"`
+	Under float64 `json:"under"`
+	Push  float64 `json:"push"`
+	Win   float64 `json:"win"`
+}
+
+// SimulationResult is the outcome of a simulation. Win is how often every leg won together, Loss
+// how often any leg lost and Push the rest; IndependentWin is the product of the legs' win rates,
+// what the combination would hit if the legs were unrelated.
+type SimulationResult struct {
+	Iterations     int                   `json:"iterations"`
+	Seed           uint64                `json:"seed"`
+	Legs           []SimulationLegResult `json:"legs"`
+	Win            float64               `json:"win"`
+	Push           float64               `json:"push"`
+	Loss           float64               `json:"loss"`
+	IndependentWin float64               `json:"independent_win"`
+	FairOdds       int                   `json:"fair_odds"`
 }
@@ -0,0 +1,305 @@
+package projection
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultDistribution is the distribution used when a request doesn't pick one
+const DefaultDistribution = "poisson"
+
+// distribution turns a projected mean and the player's game log into over, under and push
+// probabilities for a line
+type distribution struct {
+	minSample int
+	overUnder func(mean float64, sample []float64, line float64) (over, under, push float64)
+}
+
+var distributions = map[string]distribution{
+	"poisson": {
+		overUnder: func(mean float64, _ []float64, line float64) (float64, float64, float64) {
+			return PoissonOverUnder(mean, line)
+		},
+	},
+	"negative_binomial": {
+		minSample: 2,
+		overUnder: func(mean float64, sample []float64, line float64) (float64, float64, float64) {
+			return NegativeBinomialOverUnder(mean, Dispersion(sample), line)
+		},
+	},
+	"normal": {
+		minSample: 2,
+		overUnder: func(mean float64, sample []float64, line float64) (float64, float64, float64) {
+			_, variance := MeanVariance(sample)
+			return NormalOverUnder(mean, math.Sqrt(variance), line)
+		},
+	},
+	"empirical": {
+		minSample: 1,
+		overUnder: EmpiricalOverUnder,
+	},
+}
+
+// Distributions lists the distributions OverUnder accepts
+func Distributions() []string {
+	names := make([]string, 0, len(distributions))
+	for name := range distributions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MaxLine bounds the means and lines OverUnder accepts, far above any single-game stat
+const MaxLine = 10000
+
+// ValidateLine checks that a projected mean and a line are finite and within MaxLine
+func ValidateLine(mean, line float64) error {
+	if math.IsNaN(mean) || mean < 0 || mean > MaxLine {
+		return fmt.Errorf("mean must be between 0 and %d", MaxLine)
+	}
+	if math.IsNaN(line) || math.Abs(line) > MaxLine {
+		return fmt.Errorf("line must be between -%d and %d", MaxLine, MaxLine)
+	}
+	return nil
+}
+
+// OverUnder returns the probabilities that a stat projected at mean lands over, under and on
+// line under the named distribution. sample is the player's game log for the stat, which every
+// distribution but poisson needs.
+func OverUnder(name string, mean float64, sample []float64, line float64) (over, under, push float64, err error) {
+	d, ok := distributions[name]
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("unknown distribution: %s", name)
+	}
+	if err := ValidateLine(mean, line); err != nil {
+		return 0, 0, 0, err
+	}
+	if len(sample) < d.minSample {
+		return 0, 0, 0, fmt.Errorf("%s distribution needs at least %d games, got %d", name, d.minSample, len(sample))
+	}
+	over, under, push = d.overUnder(mean, sample, line)
+	return over, under, push, nil
+}
+
+// MeanVariance returns the mean and sample variance of values
+func MeanVariance(values []float64) (mean, variance float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(values)-1)
+}
+
+// Dispersion estimates the negative binomial size r from a game log by the method of moments,
+// r = mean² / (variance − mean). It returns +Inf, the Poisson limit, when the log isn't
+// overdispersed.
+func Dispersion(values []float64) float64 {
+	mean, variance := MeanVariance(values)
+	if variance <= mean || mean <= 0 {
+		return math.Inf(1)
+	}
+	return mean * mean / (variance - mean)
+}
+
+// NegativeBinomialOverUnder returns the probabilities that a negative binomial count with the
+// given mean and size r lands over, under and exactly on line. Its variance is mean + mean²/r; an
+// infinite r is Poisson.
+func NegativeBinomialOverUnder(mean, r, line float64) (over, under, push float64) {
+	if mean <= 0 || math.IsInf(r, 1) || r <= 0 {
+		return PoissonOverUnder(mean, line)
+	}
+
+	// P(X <= k) is the regularized incomplete beta I_p(r, k+1)
+	p := r / (r + mean)
+	floor := math.Floor(line)
+	if floor < 0 {
+		return 1, 0, 0
+	}
+	cdf := betaI(r, floor+1, p)
+	if floor == line {
+		lgammaKR, _ := math.Lgamma(floor + r)
+		lgammaR, _ := math.Lgamma(r)
+		lgammaK1, _ := math.Lgamma(floor + 1)
+		push = math.Exp(lgammaKR - lgammaR - lgammaK1 + r*math.Log(p) + floor*math.Log1p(-p))
+	}
+	under = max(cdf-push, 0)
+	over = 1 - cdf
+	return max(over, 0), under, push
+}
+
+// NormalOverUnder returns the probabilities that a whole-number stat, approximated as normal with
+// the given mean and standard deviation, lands over, under and exactly on line. It applies a
+// continuity correction, so a whole-number line pushes on the half point either side.
+func NormalOverUnder(mean, sd, line float64) (over, under, push float64) {
+	if sd <= 0 {
+		switch {
+		case mean > line:
+			return 1, 0, 0
+		case mean < line:
+			return 0, 1, 0
+		default:
+			return 0, 0, 1
+		}
+	}
+
+	cdf := func(x float64) float64 {
+		return 0.5 * math.Erfc(-(x-mean)/(sd*math.Sqrt2))
+	}
+	lowest := math.Ceil(line) - 0.5 // the lowest value that isn't under
+	under = cdf(lowest)
+	if line == math.Floor(line) {
+		push = cdf(line+0.5) - under
+	}
+	return 1 - under - push, under, push
+}
+
+// EmpiricalOverUnder returns the share of games in the log that land over, under and on line
+// once the log is shifted to centre on mean and rounded back to whole numbers, which is what
+// bootstrapping the log converges to. A mean of 0 leaves the log unshifted.
+func EmpiricalOverUnder(mean float64, sample []float64, line float64) (over, under, push float64) {
+	if len(sample) == 0 {
+		return 0, 0, 0
+	}
+	shift := 0.0
+	if mean > 0 {
+		sampleMean, _ := MeanVariance(sample)
+		shift = mean - sampleMean
+	}
+	for _, v := range sample {
+		switch v = math.Round(v + shift); {
+		case v > line:
+			over++
+		case v < line:
+			under++
+		default:
+			push++
+		}
+	}
+	n := float64(len(sample))
+	return over / n, under / n, push / n
+}
+
+// incompleteIterations caps the series and continued fractions below. They converge in about
+// sqrt(a) steps, so the cap only bites far beyond MaxLine.
+const incompleteIterations = 10000
+
+// gammaQ is the upper regularized incomplete gamma function Q(a, x) = Γ(a, x) / Γ(a), by its
+// series below a+1 and its continued fraction above
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgammaA, _ := math.Lgamma(a)
+	prefix := math.Exp(a*math.Log(x) - x - lgammaA)
+	if x < a+1 {
+		term, sum := 1/a, 1/a
+		for n := 1.0; n < incompleteIterations; n++ {
+			term *= x / (a + n)
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return max(1-sum*prefix, 0)
+	}
+
+	// Lentz's method for the continued fraction
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1.0; n < incompleteIterations; n++ {
+		an := -n * (n - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return min(prefix*h, 1)
+}
+
+// betaI is the regularized incomplete beta function I_x(a, b), by its continued fraction on
+// whichever side of the mean converges faster
+func betaI(a, b, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+	lgammaAB, _ := math.Lgamma(a + b)
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	prefix := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log1p(-x))
+	if x < (a+1)/(a+b+2) {
+		return min(prefix*betaFraction(a, b, x)/a, 1)
+	}
+	return max(1-prefix*betaFraction(b, a, 1-x)/b, 0)
+}
+
+// betaFraction evaluates the continued fraction for I_x(a, b) by Lentz's method
+func betaFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m < incompleteIterations; m++ {
+		// even step
+		an := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + an*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// odd step
+		an = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + an*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,117 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeanVarianceAndDispersion(t *testing.T) {
+	mean, variance := MeanVariance([]float64{2, 4, 6, 8})
+	assert.Equal(t, 5.0, mean)
+	assert.InDelta(t, 20.0/3, variance, 1e-9)
+
+	// r = 25 / (20/3 - 5)
+	assert.InDelta(t, 15, Dispersion([]float64{2, 4, 6, 8}), 1e-9)
+	assert.True(t, math.IsInf(Dispersion([]float64{5, 5, 5}), 1))
+}
+
+func TestNegativeBinomialOverUnder(t *testing.T) {
+	// mean 2, r 2 (p = 0.5): P(0)=0.25, P(1)=0.25, P(2)=0.1875
+	over, under, push := NegativeBinomialOverUnder(2, 2, 2)
+	assert.InDelta(t, 0.5, under, 1e-9)
+	assert.InDelta(t, 0.1875, push, 1e-9)
+	assert.InDelta(t, 0.3125, over, 1e-9)
+
+	// fatter tails than Poisson at the same mean
+	nbOver, _, _ := NegativeBinomialOverUnder(10, 3, 17.5)
+	poissonOver, _, _ := PoissonOverUnder(10, 17.5)
+	assert.Greater(t, nbOver, poissonOver)
+
+	// no overdispersion falls back to Poisson
+	over, under, push = NegativeBinomialOverUnder(2, math.Inf(1), 1.5)
+	pOver, pUnder, pPush := PoissonOverUnder(2, 1.5)
+	assert.Equal(t, []float64{pOver, pUnder, pPush}, []float64{over, under, push})
+
+	over, under, push = NegativeBinomialOverUnder(250, 20, 265.5)
+	assert.InDelta(t, 1, over+under+push, 1e-9)
+}
+
+func TestIncompleteCDFsMatchSummedPMFs(t *testing.T) {
+	for _, mean := range []float64{0.5, 3, 24.5, 260} {
+		for _, k := range []float64{0, 1, 5, 20, 250, 300} {
+			poisson, nb := 0.0, 0.0
+			r := 4.0
+			p := r / (r + mean)
+			for i := 0.0; i <= k; i++ {
+				poisson += poissonPMF(mean, i)
+				lgammaIR, _ := math.Lgamma(i + r)
+				lgammaR, _ := math.Lgamma(r)
+				lgammaI1, _ := math.Lgamma(i + 1)
+				nb += math.Exp(lgammaIR - lgammaR - lgammaI1 + r*math.Log(p) + i*math.Log1p(-p))
+			}
+			assert.InDelta(t, poisson, gammaQ(k+1, mean), 1e-9, "poisson mean %v k %v", mean, k)
+			assert.InDelta(t, nb, betaI(r, k+1, p), 1e-9, "negative binomial mean %v k %v", mean, k)
+		}
+	}
+}
+
+func TestOverUnderBoundsInputs(t *testing.T) {
+	// the largest accepted line is cheap, with no per-unit loop
+	over, under, _, err := OverUnder("negative_binomial", MaxLine, []float64{1, 5}, MaxLine-0.5)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, over+under, 1e-9)
+
+	for _, input := range [][2]float64{{20, 1e12}, {1e12, 20}, {math.NaN(), 20}, {20, math.Inf(1)}, {-1, 20}} {
+		_, _, _, err := OverUnder("poisson", input[0], nil, input[1])
+		assert.Error(t, err, "mean %v line %v", input[0], input[1])
+	}
+}
+
+func TestNormalOverUnder(t *testing.T) {
+	over, under, push := NormalOverUnder(20.5, 5, 20.5)
+	assert.InDelta(t, 0.5, over, 1e-9)
+	assert.InDelta(t, 0.5, under, 1e-9)
+	assert.Equal(t, 0.0, push)
+
+	// a whole-number line pushes on 19.5 to 20.5
+	over, under, push = NormalOverUnder(20, 5, 20)
+	assert.InDelta(t, 0.0797, push, 1e-4)
+	assert.InDelta(t, over, under, 1e-9)
+	assert.InDelta(t, 1, over+under+push, 1e-9)
+
+	over, under, push = NormalOverUnder(20, 0, 18.5)
+	assert.Equal(t, []float64{1, 0, 0}, []float64{over, under, push})
+}
+
+func TestEmpiricalOverUnder(t *testing.T) {
+	sample := []float64{10, 20, 20, 30}
+	over, under, push := EmpiricalOverUnder(0, sample, 20)
+	assert.Equal(t, []float64{0.25, 0.25, 0.5}, []float64{over, under, push})
+
+	// shifted up by 5 to centre on 25
+	over, under, push = EmpiricalOverUnder(25, sample, 24.5)
+	assert.Equal(t, []float64{0.75, 0.25, 0.0}, []float64{over, under, push})
+}
+
+func TestOverUnder(t *testing.T) {
+	assert.Equal(t, []string{"empirical", "negative_binomial", "normal", "poisson"}, Distributions())
+
+	over, under, _, err := OverUnder("poisson", 2, nil, 1.5)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5940, over, 1e-4)
+	assert.InDelta(t, 0.4060, under, 1e-4)
+
+	_, _, _, err = OverUnder("negative_binomial", 2, []float64{3}, 1.5)
+	assert.Error(t, err)
+	_, _, _, err = OverUnder("nope", 2, nil, 1.5)
+	assert.Error(t, err)
+
+	for _, name := range Distributions() {
+		over, under, push, err := OverUnder(name, 20, []float64{12, 18, 25, 31, 14, 22}, 20.5)
+		require.NoError(t, err, name)
+		assert.InDelta(t, 1, over+under+push, 1e-9, name)
+	}
+}
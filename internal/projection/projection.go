@@ -0,0 +1,53 @@
+// Package projection projects player stat lines from their history and turns projections into
+// over/under probabilities.
+package projection
+
+import "math"
+
+// RollingAverage is the mean of the last n values, or of all of them when there are fewer
+func RollingAverage(values []float64, n int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	if n > 0 && len(values) > n {
+		values = values[len(values)-n:]
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// PoissonOverUnder returns the probabilities that a Poisson(mean) count lands over, under and
+// exactly on line. Push is 0 for half-point lines.
+func PoissonOverUnder(mean, line float64) (over, under, push float64) {
+	if mean <= 0 {
+		if line < 0 {
+			return 1, 0, 0
+		}
+		if line == 0 {
+			return 0, 0, 1
+		}
+		return 0, 1, 0
+	}
+
+	// P(X <= k) is the upper regularized incomplete gamma Q(k+1, mean), which takes the same
+	// handful of iterations however large the line is
+	floor := math.Floor(line)
+	if floor < 0 {
+		return 1, 0, 0
+	}
+	cdf := gammaQ(floor+1, mean)
+	if floor == line {
+		push = poissonPMF(mean, floor)
+	}
+	under = max(cdf-push, 0)
+	over = 1 - cdf
+	return max(over, 0), under, push
+}
+
+func poissonPMF(mean, k float64) float64 {
+	lgamma, _ := math.Lgamma(k + 1)
+	return math.Exp(k*math.Log(mean) - mean - lgamma)
+}
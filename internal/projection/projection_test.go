@@ -0,0 +1,36 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollingAverage(t *testing.T) {
+	assert.Equal(t, 0.0, RollingAverage(nil, 5))
+	assert.Equal(t, 2.0, RollingAverage([]float64{1, 2, 3}, 5))
+	assert.Equal(t, 4.5, RollingAverage([]float64{1, 2, 3, 4, 5}, 2))
+	assert.Equal(t, 3.0, RollingAverage([]float64{1, 2, 3, 4, 5}, 0))
+}
+
+func TestPoissonOverUnder(t *testing.T) {
+	// Poisson(2): P(0)=0.1353, P(1)=0.2707, P(2)=0.2707
+	over, under, push := PoissonOverUnder(2, 1.5)
+	assert.InDelta(t, 0.4060, under, 1e-4)
+	assert.InDelta(t, 0.5940, over, 1e-4)
+	assert.Equal(t, 0.0, push)
+
+	over, under, push = PoissonOverUnder(2, 2)
+	assert.InDelta(t, 0.4060, under, 1e-4)
+	assert.InDelta(t, 0.2707, push, 1e-4)
+	assert.InDelta(t, 0.3233, over, 1e-4)
+
+	// large means stay finite and sum to one
+	over, under, push = PoissonOverUnder(250, 265.5)
+	assert.InDelta(t, 1, over+under+push, 1e-9)
+	assert.Less(t, over, 0.5)
+
+	over, under, _ = PoissonOverUnder(0, 0.5)
+	assert.Equal(t, 0.0, over)
+	assert.Equal(t, 1.0, under)
+}
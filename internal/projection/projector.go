@@ -0,0 +1,315 @@
+package projection
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+
+	"sports_api/internal/models"
+)
+
+const (
+	// DefaultModel is the model used when a request doesn't pick one
+	DefaultModel = "rolling_average"
+	// OpponentMinGames is how many earlier player-games against an opponent its factor needs
+	OpponentMinGames = 50
+)
+
+// Game is one of a player's earlier games as a projector sees it
+type Game struct {
+	Date     string
+	Opponent string
+	Value    float64
+	Minutes  float64
+}
+
+// Input is what a projector projects a game from. History holds only games played before it,
+// oldest first. Minutes is the expected minutes and OpponentFactor how much the opponent allows
+// relative to the league (1 is average); 0 means unknown for either.
+type Input struct {
+	History        []Game
+	Minutes        float64
+	OpponentFactor float64
+}
+
+// Projector projects a player's stat for their next game. Project reports false when the input
+// isn't enough to project from.
+type Projector interface {
+	Info() models.ProjectionModel
+	Project(in Input) (float64, bool)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Projector{}
+)
+
+// Register adds a projector under its Info name. It panics on an empty or duplicate name, like
+// database/sql.Register.
+func Register(p Projector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	name := p.Info().Name
+	if name == "" {
+		panic("projection: Register projector with empty name")
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("projection: Register called twice for model %s", name))
+	}
+	registry[name] = p
+}
+
+// Lookup returns the projector registered under name
+func Lookup(name string) (Projector, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[name]
+	return p, ok
+}
+
+// Models lists the registered models by name
+func Models() []models.ProjectionModel {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	list := make([]models.ProjectionModel, 0, len(registry))
+	for _, p := range registry {
+		list = append(list, p.Info())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Uses reports whether a model reads an input beyond the player's game log
+func Uses(p Projector, input string) bool {
+	return slices.Contains(p.Info().Inputs, input)
+}
+
+// projectorFunc adapts a function to Projector
+type projectorFunc struct {
+	info    models.ProjectionModel
+	project func(in Input) float64
+}
+
+func (p projectorFunc) Info() models.ProjectionModel {
+	info := p.info
+	info.Inputs = append([]string{}, p.info.Inputs...)
+	return info
+}
+
+func (p projectorFunc) Project(in Input) (float64, bool) {
+	if len(in.History) < max(p.info.MinGames, 1) {
+		return 0, false
+	}
+	return p.project(in), true
+}
+
+const (
+	rollingWindow   = 10
+	halfLifeGames   = 5.0
+	perMinuteWindow = 10
+)
+
+func init() {
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "naive_average",
+			Description: "Mean of every game in the player's history",
+			MinGames:    1,
+			Inputs:      []string{},
+		},
+		project: func(in Input) float64 {
+			return RollingAverage(values(in.History), 0)
+		},
+	})
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "rolling_average",
+			Description: "Mean of the player's last games",
+			MinGames:    1,
+			Inputs:      []string{},
+			Parameters:  map[string]any{"window": rollingWindow},
+		},
+		project: func(in Input) float64 {
+			return RollingAverage(values(in.History), rollingWindow)
+		},
+	})
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "weighted_recent",
+			Description: "Exponentially weighted mean favouring recent form",
+			MinGames:    1,
+			Inputs:      []string{},
+			Parameters:  map[string]any{"half_life_games": halfLifeGames},
+		},
+		project: func(in Input) float64 {
+			return weightedRecent(in.History)
+		},
+	})
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "per_minute",
+			Description: "Per-minute rate over the player's last games times expected minutes, defaulting to their average minutes over those games",
+			MinGames:    1,
+			Inputs:      []string{"minutes"},
+			Parameters:  map[string]any{"window": perMinuteWindow},
+		},
+		project: func(in Input) float64 {
+			return perMinute(in.History, in.Minutes)
+		},
+	})
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "opponent_adjusted",
+			Description: "Weighted recent form scaled by how much the opponent allows relative to the league",
+			MinGames:    1,
+			Inputs:      []string{"opponent"},
+			Parameters:  map[string]any{"half_life_games": halfLifeGames},
+		},
+		project: func(in Input) float64 {
+			projected := weightedRecent(in.History)
+			if in.OpponentFactor > 0 {
+				projected *= in.OpponentFactor
+			}
+			return projected
+		},
+	})
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "poisson",
+			Description: "Median of a Poisson count fitted to the player's last games, the line a count model prices at even odds",
+			MinGames:    1,
+			Inputs:      []string{},
+			Parameters:  map[string]any{"window": rollingWindow},
+		},
+		project: func(in Input) float64 {
+			return countMedian(RollingAverage(values(in.History), rollingWindow), math.Inf(1))
+		},
+	})
+	Register(projectorFunc{
+		info: models.ProjectionModel{
+			Name:        "negative_binomial",
+			Description: "Median of a negative binomial count fitted by moments to the player's last games, for stats more spread out than Poisson",
+			MinGames:    2,
+			Inputs:      []string{},
+			Parameters:  map[string]any{"window": rollingWindow},
+		},
+		project: func(in Input) float64 {
+			recent := values(in.History)
+			if len(recent) > rollingWindow {
+				recent = recent[len(recent)-rollingWindow:]
+			}
+			return countMedian(RollingAverage(recent, 0), Dispersion(recent))
+		},
+	})
+}
+
+func values(games []Game) []float64 {
+	values := make([]float64, len(games))
+	for i, g := range games {
+		values[i] = g.Value
+	}
+	return values
+}
+
+// weightedRecent weights each game by half every halfLifeGames games back
+func weightedRecent(games []Game) float64 {
+	sum, weights := 0.0, 0.0
+	for i, g := range games {
+		w := math.Pow(0.5, float64(len(games)-1-i)/halfLifeGames)
+		sum += w * g.Value
+		weights += w
+	}
+	if weights == 0 {
+		return 0
+	}
+	return sum / weights
+}
+
+// perMinute projects the rate per minute over the last games, times minutes when known
+func perMinute(games []Game, minutes float64) float64 {
+	if len(games) > perMinuteWindow {
+		games = games[len(games)-perMinuteWindow:]
+	}
+	total, played := 0.0, 0.0
+	for _, g := range games {
+		total += g.Value
+		played += g.Minutes
+	}
+	if played == 0 {
+		return RollingAverage(values(games), 0)
+	}
+	if minutes <= 0 {
+		minutes = played / float64(len(games))
+	}
+	return total / played * minutes
+}
+
+// countMedian is the smallest count k with P(X <= k) >= 0.5 for a negative binomial with the given
+// mean and size r (Poisson when r is infinite). It walks from the mean, which is within a few
+// counts of the median.
+func countMedian(mean, r float64) float64 {
+	if mean <= 0 {
+		return 0
+	}
+	cdf := func(k float64) float64 {
+		_, under, _ := NegativeBinomialOverUnder(mean, r, k+0.5)
+		return under
+	}
+	k := math.Floor(mean)
+	for k > 0 && cdf(k-1) >= 0.5 {
+		k--
+	}
+	for cdf(k) < 0.5 {
+		k++
+	}
+	return k
+}
+
+// OpponentFactors computes, for each game, how much that game's opponent allowed per player-game
+// relative to the league, using only games on earlier dates. Games whose opponent has fewer than
+// minGames earlier player-games get 1.
+func OpponentFactors(games []Game, minGames int) []float64 {
+	order := make([]int, len(games))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return games[order[a]].Date < games[order[b]].Date })
+
+	type totals struct {
+		sum float64
+		n   int
+	}
+	league := totals{}
+	byOpponent := map[string]*totals{}
+	factors := make([]float64, len(games))
+
+	for start := 0; start < len(order); {
+		end := start
+		for end < len(order) && games[order[end]].Date == games[order[start]].Date {
+			end++
+		}
+		for _, i := range order[start:end] {
+			factors[i] = 1
+			opp := byOpponent[games[i].Opponent]
+			if opp != nil && opp.n >= minGames && league.sum > 0 {
+				factors[i] = (opp.sum / float64(opp.n)) / (league.sum / float64(league.n))
+			}
+		}
+		for _, i := range order[start:end] {
+			g := games[i]
+			if byOpponent[g.Opponent] == nil {
+				byOpponent[g.Opponent] = &totals{}
+			}
+			byOpponent[g.Opponent].sum += g.Value
+			byOpponent[g.Opponent].n++
+			league.sum += g.Value
+			league.n++
+		}
+		start = end
+	}
+	return factors
+}
@@ -0,0 +1,100 @@
+package projection
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func history(values ...float64) []Game {
+	games := make([]Game, len(values))
+	for i, v := range values {
+		games[i] = Game{Value: v, Minutes: 30}
+	}
+	return games
+}
+
+func TestRegistry(t *testing.T) {
+	names := []string{}
+	for _, m := range Models() {
+		names = append(names, m.Name)
+	}
+	assert.Equal(t, []string{"naive_average", "negative_binomial", "opponent_adjusted", "per_minute", "poisson", "rolling_average", "weighted_recent"}, names)
+
+	_, ok := Lookup(DefaultModel)
+	assert.True(t, ok)
+	_, ok = Lookup("nope")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { Register(projectorFunc{info: models.ProjectionModel{Name: DefaultModel}}) })
+	assert.Panics(t, func() { Register(projectorFunc{}) })
+}
+
+func TestBuiltinProjectors(t *testing.T) {
+	project := func(name string, in Input) float64 {
+		v, ok := mustLookup(t, name).Project(in)
+		require.True(t, ok)
+		return v
+	}
+
+	games := history(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 40)
+	assert.InDelta(t, 12.5, project("naive_average", Input{History: games}), 1e-9)
+	assert.InDelta(t, 13, project("rolling_average", Input{History: games}), 1e-9)
+
+	// the latest game carries the most weight
+	weighted := project("weighted_recent", Input{History: games})
+	assert.Greater(t, weighted, 13.0)
+	assert.Less(t, weighted, 40.0)
+
+	// 0.5 points a minute over the last ten games
+	games = history(15, 15, 15)
+	assert.InDelta(t, 15, project("per_minute", Input{History: games}), 1e-9)
+	assert.InDelta(t, 18, project("per_minute", Input{History: games, Minutes: 36}), 1e-9)
+
+	assert.InDelta(t, 15, project("opponent_adjusted", Input{History: games}), 1e-9)
+	assert.InDelta(t, 18, project("opponent_adjusted", Input{History: games, OpponentFactor: 1.2}), 1e-9)
+
+	// Poisson(13) has median 13; the spread-out log has a lower median than its mean of 13
+	games = history(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 40)
+	assert.Equal(t, 13.0, project("poisson", Input{History: games}))
+	assert.Less(t, project("negative_binomial", Input{History: games}), 13.0)
+	// no overdispersion falls back to Poisson
+	games = history(12, 13, 14)
+	assert.Equal(t, project("poisson", Input{History: games}), project("negative_binomial", Input{History: games}))
+
+	p := mustLookup(t, "rolling_average")
+	_, ok := p.Project(Input{})
+	assert.False(t, ok)
+	assert.True(t, Uses(mustLookup(t, "per_minute"), "minutes"))
+	assert.False(t, Uses(p, "minutes"))
+}
+
+func mustLookup(t *testing.T, name string) Projector {
+	p, ok := Lookup(name)
+	require.True(t, ok)
+	return p
+}
+
+func TestOpponentFactors(t *testing.T) {
+	games := []Game{
+		{Date: "2024-01-01", Opponent: "BOS", Value: 30},
+		{Date: "2024-01-01", Opponent: "NYK", Value: 10},
+		{Date: "2024-01-02", Opponent: "BOS", Value: 20},
+		{Date: "2024-01-02", Opponent: "NYK", Value: 20},
+		{Date: "2024-01-02", Opponent: "MIA", Value: 20},
+	}
+	factors := OpponentFactors(games, 1)
+
+	// nothing is known on the first date
+	assert.Equal(t, 1.0, factors[0])
+	assert.Equal(t, 1.0, factors[1])
+	// league allowed 20 a game on earlier dates: BOS 30, NYK 10, MIA unseen
+	assert.InDelta(t, 1.5, factors[2], 1e-9)
+	assert.InDelta(t, 0.5, factors[3], 1e-9)
+	assert.Equal(t, 1.0, factors[4])
+
+	assert.Equal(t, []float64{1, 1, 1, 1, 1}, OpponentFactors(games, 2))
+}
@@ -9,25 +9,54 @@ import (
 )
 
 // SetupNBARoutes configures all NBA-related routes under the given group.
-func SetupNBARoutes(router *gin.RouterGroup, db *sql.DB) {
+func SetupNBARoutes(router *gin.RouterGroup, db *sql.DB, auth gin.HandlerFunc) {
 	nbaHandler := handlers.NewNBAHandler(db)
 
 	nba := router.Group("/nba")
+	playerPath := func(suffix string) string { return nba.BasePath() + "/players/id/:player_id" + suffix }
+	teamPath := func(suffix string) string { return nba.BasePath() + "/teams/:team_id" + suffix }
 	{
 		nba.GET("/teams", nbaHandler.GetNBATeams)
-		nba.GET("/players-shotchart/:player_name/:season_id", nbaHandler.GetPlayerShotChartStats)
-		nba.GET("/players-shotchart/averages/:player_name/:season_id", nbaHandler.GetPlayerAvgShotChartStats)
-		nba.GET("/team-roster/:city", nbaHandler.GetTeamRoster)
-		nba.GET("/player/:name/last/:last_number_of_games/games", nbaHandler.GetPlayerLastXGames)
-		nba.GET("/players/:city", nbaHandler.GetNBAPlayersByTeam)
-		nba.GET("/team/:city/last/:number_of_days/games", nbaHandler.GetTeamLastXGames)
-		nba.GET("/defense-stats/:team_name", nbaHandler.GetTeamDefenseStats)
-		nba.GET("/offense-stats/:team_name", nbaHandler.GetTeamOffenseStats)
-		nba.GET("/shooting-splits/:player_name", nbaHandler.GetPlayerShootingSplits)
-		nba.GET("/headline-stats/:player_name", nbaHandler.GetPlayerHeadlineStats)
-		nba.POST("/points-prediction/:player_name", nbaHandler.PointsPrediction)
-		nba.POST("/poisson-dist", nbaHandler.GetPoissonDistribution)
+		nba.GET("/seasons", nbaHandler.GetNBASeasons)
+		nba.GET("/team-roster/:city", nbaHandler.RedirectTeamName("city", teamPath("/roster")))
+		nba.GET("/players/:city", nbaHandler.RedirectTeamName("city", teamPath("/players")))
+		nba.GET("/team/:city/last/:number_of_days/games", nbaHandler.RedirectTeamName("city", teamPath("/last/:number_of_days/games")))
+		nba.GET("/defense-stats/:team_name", nbaHandler.RedirectTeamName("team_name", teamPath("/defense-stats")))
+		nba.GET("/offense-stats/:team_name", nbaHandler.RedirectTeamName("team_name", teamPath("/offense-stats")))
+		nba.GET("/defense-vs-position/:season_id", nbaHandler.GetDefenseVsPosition)
+		nba.GET("/players-shotchart/:player_name/:season_id", nbaHandler.RedirectPlayerName("player_name", playerPath("/shotchart/:season_id")))
+		nba.GET("/players-shotchart/averages/:player_name/:season_id", nbaHandler.RedirectPlayerName("player_name", playerPath("/shotchart/averages/:season_id")))
+		nba.GET("/player/:name/last/:last_number_of_games/games", nbaHandler.RedirectPlayerName("name", playerPath("/last/:last_number_of_games/games")))
+		nba.GET("/shooting-splits/:player_name", nbaHandler.RedirectPlayerName("player_name", playerPath("/shooting-splits")))
+		nba.GET("/headline-stats/:player_name", nbaHandler.RedirectPlayerName("player_name", playerPath("/headline-stats")))
+		nba.POST("/points-prediction/:player_name", nbaHandler.RedirectPlayerName("player_name", playerPath("/points-prediction")))
+
+		// ID routes; the name routes above redirect here
+		nba.GET("/teams/:team_id/roster", nbaHandler.TeamCityByID("city", nbaHandler.GetTeamRoster))
+		nba.GET("/teams/:team_id/players", nbaHandler.TeamCityByID("city", nbaHandler.GetNBAPlayersByTeam))
+		nba.GET("/teams/:team_id/last/:number_of_days/games", nbaHandler.TeamCityByID("city", nbaHandler.GetTeamLastXGames))
+		nba.GET("/teams/:team_id/defense-stats", nbaHandler.TeamNameByID("team_name", nbaHandler.GetTeamDefenseStats))
+		nba.GET("/teams/:team_id/offense-stats", nbaHandler.TeamNameByID("team_name", nbaHandler.GetTeamOffenseStats))
+		nba.GET("/teams/:team_id/opponent-shooting/by-zone/:season", nbaHandler.TeamNameByID("opponent", nbaHandler.GetOpponentShootingByZone))
+		nba.GET("/teams/:team_id/with-without", nbaHandler.TeamNameByID("team_name", nbaHandler.GetWithWithoutSplits))
+		nba.GET("/players/id/:player_id/shotchart/:season_id", nbaHandler.PlayerByID("player_name", nbaHandler.GetPlayerShotChartStats))
+		nba.GET("/players/id/:player_id/shotchart/averages/:season_id", nbaHandler.PlayerByID("player_name", nbaHandler.GetPlayerAvgShotChartStats))
+		nba.GET("/players/id/:player_id/last/:last_number_of_games/games", nbaHandler.PlayerByID("name", nbaHandler.GetPlayerLastXGames))
+		nba.GET("/players/id/:player_id/shooting-splits", nbaHandler.PlayerByID("player_name", nbaHandler.GetPlayerShootingSplits))
+		nba.GET("/players/id/:player_id/headline-stats", nbaHandler.PlayerByID("player_name", nbaHandler.GetPlayerHeadlineStats))
+		nba.POST("/players/id/:player_id/points-prediction", nbaHandler.PlayerByID("player_name", nbaHandler.PointsPrediction))
+
+		nba.GET("/injuries", nbaHandler.GetInjuries)
+		nba.GET("/injuries/history", nbaHandler.GetInjuryHistory)
+		nba.GET("/injuries/changes", nbaHandler.GetInjuryChanges)
+		nba.GET("/identity/unmatched", nbaHandler.GetUnmatchedPlayerNames)
+		nba.GET("/identity/resolve", nbaHandler.ResolvePlayerName)
+		nba.POST("/identity/aliases", auth, nbaHandler.AddPlayerAlias)
+
+		nba.POST("/poisson-dist", nbaHandler.GetDistribution)
+		nba.POST("/distribution", nbaHandler.GetDistribution)
 		nba.GET("/scoreboard", nbaHandler.GetScoreboard)
+		nba.GET("/backtest", nbaHandler.Backtest)
 		nba.GET("/odds/:market/:name", nbaHandler.GetPropOdds)
 		nba.GET("/odds/moneyline/:team", nbaHandler.GetMoneylineOdds)
 
@@ -35,7 +64,7 @@ func SetupNBARoutes(router *gin.RouterGroup, db *sql.DB) {
 		// 	nba.GET("/opponent-shooting/by-zone", nbaHandler.GetOpponentShootingByZone)
 		// // Path param forms (your logs show :opponent)
 		// 	nba.GET("/opponent-shooting/by-zone/:opponent", nbaHandler.GetOpponentShootingByZone)
-		nba.GET("/opponent-shooting/by-zone/:opponent/:season", nbaHandler.GetOpponentShootingByZone)
+		nba.GET("/opponent-shooting/by-zone/:opponent/:season", nbaHandler.RedirectTeamName("opponent", teamPath("/opponent-shooting/by-zone/:season")))
 
 	}
 }
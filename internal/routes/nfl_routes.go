@@ -14,17 +14,59 @@ func SetupNFLRoutes(router *gin.RouterGroup, db *sql.DB) {
 
 	// NFL routes
 	nfl := router.Group("/nfl")
+	playerPath := func(suffix string) string { return nfl.BasePath() + "/players/id/:player_id" + suffix }
+	teamPath := func(suffix string) string { return nfl.BasePath() + "/teams/:team_id" + suffix }
 	{
 		nfl.GET("/teams", playerHandler.GetAllTeams)
-		nfl.GET("/team-roster/:team", playerHandler.GetPlayersByTeam)
-		nfl.GET("/players/:player/rushing-stats", playerHandler.GetPlayerRushingStats)
-		nfl.GET("/players/:player/receiving-stats", playerHandler.GetPlayerReceivingStats)
-		nfl.GET("/players/:player/passing-stats", playerHandler.GetPlayerPassingStats)
-		nfl.GET("/players/:player/rushing-receiving-game-stats", playerHandler.GetRushingGameStats)
-		nfl.GET("/players/:player/passing-game-stats", playerHandler.GetPassingGameStats)
-		nfl.GET("/team-defense-stats/:team", playerHandler.GetTeamDefenseStats)
-		nfl.GET("/team-offense-stats/:team", playerHandler.GetTeamOffenseStats)
-		nfl.GET("/players/:player/passing-pbp-stats/:season", playerHandler.GetNFLPassingPBPStats)
+		nfl.GET("/team-roster/:team", playerHandler.RedirectTeamName("team", teamPath("/roster")))
+		nfl.GET("/players/:player/rushing-stats", playerHandler.RedirectPlayerName("player", playerPath("/rushing-stats")))
+		nfl.GET("/players/:player/receiving-stats", playerHandler.RedirectPlayerName("player", playerPath("/receiving-stats")))
+		nfl.GET("/players/:player/passing-stats", playerHandler.RedirectPlayerName("player", playerPath("/passing-stats")))
+		nfl.GET("/players/:player/rushing-career", playerHandler.RedirectPlayerName("player", playerPath("/rushing-career")))
+		nfl.GET("/players/:player/receiving-career", playerHandler.RedirectPlayerName("player", playerPath("/receiving-career")))
+		nfl.GET("/players/:player/passing-career", playerHandler.RedirectPlayerName("player", playerPath("/passing-career")))
+		nfl.GET("/players/:player/rushing-receiving-game-stats", playerHandler.RedirectPlayerName("player", playerPath("/rushing-receiving-game-stats")))
+		nfl.GET("/players/:player/passing-game-stats", playerHandler.RedirectPlayerName("player", playerPath("/passing-game-stats")))
+		nfl.GET("/team-defense-stats/:team", playerHandler.RedirectTeamName("team", teamPath("/defense-stats")))
+		nfl.GET("/team-offense-stats/:team", playerHandler.RedirectTeamName("team", teamPath("/offense-stats")))
+		nfl.GET("/v2/team-defense-stats/:team", playerHandler.RedirectTeamName("team", teamPath("/v2/defense-stats")))
+		nfl.GET("/v2/team-offense-stats/:team", playerHandler.RedirectTeamName("team", teamPath("/v2/offense-stats")))
+		nfl.GET("/rankings/defense", playerHandler.GetTeamDefenseRankings)
+		nfl.GET("/rankings/offense", playerHandler.GetTeamOffenseRankings)
+		nfl.GET("/defense-vs-position/:season", playerHandler.GetDefenseVsPosition)
+		nfl.GET("/players/:player/passing-pbp-stats/:season", playerHandler.RedirectPasserName("player", playerPath("/passing-pbp-stats/:season")))
+		nfl.GET("/players/:player/passing-grid/:season", playerHandler.RedirectPasserName("player", playerPath("/passing-grid/:season")))
+		nfl.GET("/team-passing-grid-allowed/:team/:season", playerHandler.RedirectTeamName("team", teamPath("/passing-grid-allowed/:season")))
+		nfl.GET("/players/:player/usage", playerHandler.RedirectPlayerName("player", playerPath("/usage")))
+		nfl.GET("/team-usage/:team", playerHandler.RedirectTeamName("team", teamPath("/usage")))
+		nfl.GET("/players/:player/snaps", playerHandler.RedirectPlayerName("player", playerPath("/snaps")))
+		nfl.GET("/team-snaps/:team", playerHandler.RedirectTeamName("team", teamPath("/snaps")))
+		nfl.GET("/snap-movers/:week", playerHandler.GetSnapShareMovers)
 		nfl.GET("/odds/:market/:name", playerHandler.GetNFLPropOdds)
+		nfl.GET("/schedule", playerHandler.GetSchedule)
+		nfl.GET("/week/:week", playerHandler.GetWeekScoreboard)
+		nfl.POST("/distribution", playerHandler.GetDistribution)
+
+		// ID routes; the player and team name routes above redirect here
+		nfl.GET("/teams/:team_id/roster", playerHandler.TeamByID("team", playerHandler.GetPlayersByTeam))
+		nfl.GET("/teams/:team_id/defense-stats", playerHandler.TeamByID("team", playerHandler.GetTeamDefenseStats))
+		nfl.GET("/teams/:team_id/offense-stats", playerHandler.TeamByID("team", playerHandler.GetTeamOffenseStats))
+		nfl.GET("/teams/:team_id/v2/defense-stats", playerHandler.TeamByID("team", playerHandler.GetTeamDefenseStatsV2))
+		nfl.GET("/teams/:team_id/v2/offense-stats", playerHandler.TeamByID("team", playerHandler.GetTeamOffenseStatsV2))
+		nfl.GET("/teams/:team_id/passing-grid-allowed/:season", playerHandler.TeamByID("team", playerHandler.GetNFLDefensePassingGrid))
+		nfl.GET("/teams/:team_id/usage", playerHandler.TeamByID("team", playerHandler.GetTeamUsageLeaderboard))
+		nfl.GET("/teams/:team_id/snaps", playerHandler.TeamByID("team", playerHandler.GetTeamSnapTrends))
+		nfl.GET("/players/id/:player_id/rushing-stats", playerHandler.PlayerByID("player", playerHandler.GetPlayerRushingStats))
+		nfl.GET("/players/id/:player_id/receiving-stats", playerHandler.PlayerByID("player", playerHandler.GetPlayerReceivingStats))
+		nfl.GET("/players/id/:player_id/passing-stats", playerHandler.PlayerByID("player", playerHandler.GetPlayerPassingStats))
+		nfl.GET("/players/id/:player_id/rushing-career", playerHandler.PlayerByID("player", playerHandler.GetPlayerRushingCareer))
+		nfl.GET("/players/id/:player_id/receiving-career", playerHandler.PlayerByID("player", playerHandler.GetPlayerReceivingCareer))
+		nfl.GET("/players/id/:player_id/passing-career", playerHandler.PlayerByID("player", playerHandler.GetPlayerPassingCareer))
+		nfl.GET("/players/id/:player_id/rushing-receiving-game-stats", playerHandler.PlayerByID("player", playerHandler.GetRushingGameStats))
+		nfl.GET("/players/id/:player_id/passing-game-stats", playerHandler.PlayerByID("player", playerHandler.GetPassingGameStats))
+		nfl.GET("/players/id/:player_id/passing-pbp-stats/:season", playerHandler.PlayerByID("player", playerHandler.GetNFLPassingPBPStats))
+		nfl.GET("/players/id/:player_id/passing-grid/:season", playerHandler.PlayerByID("player", playerHandler.GetNFLPassingGrid))
+		nfl.GET("/players/id/:player_id/usage", playerHandler.PlayerByID("player", playerHandler.GetPlayerUsage))
+		nfl.GET("/players/id/:player_id/snaps", playerHandler.PlayerByID("player", playerHandler.GetPlayerSnapTrend))
 	}
 }
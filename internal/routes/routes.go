@@ -2,22 +2,64 @@ package routes
 
 import (
 	"database/sql"
+	"log"
+	"os"
 
 	"github.com/gin-gonic/gin"
+	"sports_api/internal/database"
 	"sports_api/internal/handlers"
 )
 
 // SetupRoutes configures all API routes
 func SetupRoutes(router *gin.Engine, db *sql.DB) {
+	// The player identity resolver behind the NBA, search, bet and simulation routes reads aliases
+	// from an app-owned table
+	if err := database.EnsureIdentityTables(db); err != nil {
+		log.Printf("identity: %v", err)
+	}
+
 	// API v1 routes
 	api := router.Group("/api/v1")
 	{
 		// Health check
 		api.GET("/health", handlers.HealthCheck)
 
+		// Player and team search across sports
+		api.GET("/search", handlers.NewSearchHandler(db).Search)
+		api.GET("/models", handlers.GetModels)
+		api.POST("/simulate", handlers.NewSimulationHandler(db).Simulate)
+
+		// Live odds changes over Server-Sent Events and NBA scoreboard over WebSocket
+		oddsHub := handlers.NewOddsHub(db)
+		streamHandler := handlers.NewStreamHandler(db, oddsHub)
+		api.GET("/stream/odds", streamHandler.Odds)
+		api.GET("/stream/scoreboard", streamHandler.Scoreboard)
+
+		// Per-user and write routes act for the caller authenticated by a bearer token signed with
+		// AUTH_TOKEN_SECRET; without the secret they refuse every request
+		secret := os.Getenv("AUTH_TOKEN_SECRET")
+		if secret == "" {
+			log.Printf("auth: AUTH_TOKEN_SECRET is not set, authenticated routes will refuse every request")
+		}
+		auth := handlers.Authenticate([]byte(secret))
+		me := api.Group("/me", auth)
+
+		// Per-user line-movement alerts delivered to webhooks
+		alertHandler := handlers.NewAlertHandler(db, oddsHub)
+		me.POST("/alerts", alertHandler.CreateAlertRule)
+		me.GET("/alerts", alertHandler.GetAlertRules)
+		me.DELETE("/alerts/:alert_id", alertHandler.DeleteAlertRule)
+		me.GET("/alerts/deliveries", alertHandler.GetAlertDeliveries)
+
+		// Per-user bet ledger graded from the gamelogs
+		betHandler := handlers.NewBetHandler(db)
+		me.POST("/bets", betHandler.CreateBet)
+		me.GET("/bets", betHandler.GetBets)
+		me.GET("/bets/summary", betHandler.GetBetSummary)
+
 		// Setup sport-specific routes
 		SetupNFLRoutes(api, db)
-		SetupNBARoutes(api, db)
+		SetupNBARoutes(api, db, auth)
 		
 		// Example of adding a new sport (MLB)
 		// Uncomment the line below when MLB handlers are implemented
@@ -0,0 +1,298 @@
+// Package simulation draws correlated stat lines for players from the covariance of their game
+// logs and scores combinations of prop legs, such as combo props and same-game parlays, against
+// them.
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"sports_api/internal/betting"
+	"sports_api/internal/models"
+)
+
+const (
+	DefaultIterations = 10000
+	MaxIterations     = 200000
+	// minGames is how many games a player's log needs to estimate a spread
+	minGames = 2
+	// minSharedGames is how many games two players need together before they're correlated
+	minSharedGames = 5
+)
+
+// Leg is a prop leg to score with the gamelog columns its market adds up
+type Leg struct {
+	models.SimulationLeg
+	Components []string
+}
+
+// component is one player's gamelog column
+type component struct {
+	player, column string
+	mean, floor    float64
+}
+
+// Simulate draws iterations joint outcomes for the legs' players, whose game logs are keyed by
+// leg player, and reports how often each leg and the whole combination won. Stats are drawn as a
+// multivariate normal with each player's own covariance across their games and, for players with
+// enough games together, the correlation seen in those games; draws are rounded to whole numbers
+// and kept at or above zero, or the player's lowest game when that was negative.
+func Simulate(logs map[string][]models.StatGame, legs []Leg, iterations int, seed uint64) (models.SimulationResult, error) {
+	if len(legs) == 0 {
+		return models.SimulationResult{}, fmt.Errorf("at least one leg is required")
+	}
+	if iterations <= 0 {
+		iterations = DefaultIterations
+	}
+
+	components, legComponents := indexComponents(legs)
+	for _, leg := range legs {
+		if len(logs[leg.Player]) < minGames {
+			return models.SimulationResult{}, fmt.Errorf("%s has %d games, need at least %d", leg.Player, len(logs[leg.Player]), minGames)
+		}
+	}
+	for i := range components {
+		c := &components[i]
+		values := columnValues(logs[c.player], c.column)
+		c.mean = mean(values)
+		for _, v := range values {
+			c.floor = min(c.floor, v)
+		}
+	}
+
+	chol := choleskyWithShrinkage(covariance(logs, components), components)
+
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	n := len(components)
+	z := make([]float64, n)
+	draw := make([]float64, n)
+	legResults := make([]struct{ over, under, push, win int }, len(legs))
+	result := models.SimulationResult{Iterations: iterations, Seed: seed}
+	wins, pushes := 0, 0
+
+	for range iterations {
+		for i := range z {
+			z[i] = rng.NormFloat64()
+		}
+		for i, c := range components {
+			v := c.mean
+			for j := 0; j <= i; j++ {
+				v += chol[i][j] * z[j]
+			}
+			draw[i] = max(math.Round(v), c.floor)
+		}
+
+		allWon, anyLost := true, false
+		for l, leg := range legs {
+			value := 0.0
+			for _, i := range legComponents[l] {
+				value += draw[i]
+			}
+			r := &legResults[l]
+			switch {
+			case value > leg.Line:
+				r.over++
+			case value < leg.Line:
+				r.under++
+			default:
+				r.push++
+			}
+			switch betting.Result(leg.Side, leg.Line, value) {
+			case "won":
+				r.win++
+			case "lost":
+				anyLost = true
+				allWon = false
+			default:
+				allWon = false
+			}
+		}
+		switch {
+		case allWon:
+			wins++
+		case !anyLost:
+			pushes++
+		}
+	}
+
+	total := float64(iterations)
+	result.IndependentWin = 1
+	for l, leg := range legs {
+		r := legResults[l]
+		legMean := 0.0
+		for _, i := range legComponents[l] {
+			legMean += components[i].mean
+		}
+		legResult := models.SimulationLegResult{
+			SimulationLeg: leg.SimulationLeg,
+			Games:         len(logs[leg.Player]),
+			Mean:          legMean,
+			Over:          float64(r.over) / total,
+			Under:         float64(r.under) / total,
+			Push:          float64(r.push) / total,
+			Win:           float64(r.win) / total,
+		}
+		result.Legs = append(result.Legs, legResult)
+		result.IndependentWin *= legResult.Win
+	}
+	result.Win = float64(wins) / total
+	result.Push = float64(pushes) / total
+	result.Loss = 1 - result.Win - result.Push
+	result.FairOdds = betting.AmericanOdds(result.Win)
+	return result, nil
+}
+
+// indexComponents lists the distinct player columns across legs and which of them each leg adds up
+func indexComponents(legs []Leg) ([]component, [][]int) {
+	var components []component
+	index := map[[2]string]int{}
+	legComponents := make([][]int, len(legs))
+	for l, leg := range legs {
+		for _, column := range leg.Components {
+			key := [2]string{leg.Player, column}
+			i, ok := index[key]
+			if !ok {
+				i = len(components)
+				index[key] = i
+				components = append(components, component{player: leg.Player, column: column})
+			}
+			legComponents[l] = append(legComponents[l], i)
+		}
+	}
+	return components, legComponents
+}
+
+// covariance estimates the covariance between components. A player's own columns covary across
+// all their games; two players' columns take the correlation from their games together, scaled
+// by each column's spread over all of its player's games, or 0 with too few games together.
+func covariance(logs map[string][]models.StatGame, components []component) [][]float64 {
+	n := len(components)
+	cov := make([][]float64, n)
+	for i := range cov {
+		cov[i] = make([]float64, n)
+	}
+
+	sd := make([]float64, n)
+	for i, c := range components {
+		values := columnValues(logs[c.player], c.column)
+		sd[i] = math.Sqrt(sampleCovariance(values, values))
+	}
+
+	for i := range n {
+		for j := 0; j <= i; j++ {
+			a, b := components[i], components[j]
+			var c float64
+			if a.player == b.player {
+				games := logs[a.player]
+				c = sampleCovariance(columnValues(games, a.column), columnValues(games, b.column))
+			} else {
+				xs, ys := sharedValues(logs[a.player], logs[b.player], a.column, b.column)
+				if len(xs) >= minSharedGames {
+					sx := math.Sqrt(sampleCovariance(xs, xs))
+					sy := math.Sqrt(sampleCovariance(ys, ys))
+					if sx > 0 && sy > 0 {
+						c = sampleCovariance(xs, ys) / (sx * sy) * sd[i] * sd[j]
+					}
+				}
+			}
+			cov[i][j], cov[j][i] = c, c
+		}
+	}
+	return cov
+}
+
+// choleskyWithShrinkage factors cov, halving the correlations between different players until it
+// factors; a player's own block is always positive semi-definite, so dropping them always works
+func choleskyWithShrinkage(cov [][]float64, components []component) [][]float64 {
+	for attempt := 0; ; attempt++ {
+		if chol, ok := cholesky(cov); ok {
+			return chol
+		}
+		scale := 0.5
+		if attempt >= 4 {
+			scale = 0
+		}
+		for i := range cov {
+			for j := range cov {
+				if components[i].player != components[j].player {
+					cov[i][j] *= scale
+				}
+			}
+		}
+	}
+}
+
+// cholesky returns the lower-triangular L with L·Lᵀ = cov. A tiny jitter on the diagonal lets
+// columns with no spread, and columns that always move together, factor.
+func cholesky(cov [][]float64) ([][]float64, bool) {
+	n := len(cov)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := range n {
+		for j := 0; j <= i; j++ {
+			sum := cov[i][j]
+			for k := range j {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				sum += 1e-9 * (1 + cov[i][i])
+				if sum <= 0 {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+func columnValues(games []models.StatGame, column string) []float64 {
+	values := make([]float64, len(games))
+	for i, g := range games {
+		values[i] = g.Stats[column]
+	}
+	return values
+}
+
+// sharedValues pairs two players' columns over the games they both played
+func sharedValues(a, b []models.StatGame, columnA, columnB string) (xs, ys []float64) {
+	byGame := make(map[string]float64, len(b))
+	for _, g := range b {
+		byGame[g.GameID] = g.Stats[columnB]
+	}
+	for _, g := range a {
+		if y, ok := byGame[g.GameID]; ok {
+			xs = append(xs, g.Stats[columnA])
+			ys = append(ys, y)
+		}
+	}
+	return xs, ys
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func sampleCovariance(xs, ys []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	mx, my := mean(xs), mean(ys)
+	sum := 0.0
+	for i := range xs {
+		sum += (xs[i] - mx) * (ys[i] - my)
+	}
+	return sum / float64(len(xs)-1)
+}
@@ -0,0 +1,118 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gameLog builds a log of games g1, g2, ... with the given column values
+func gameLog(columns map[string][]float64) []models.StatGame {
+	var games []models.StatGame
+	for column, values := range columns {
+		for i, v := range values {
+			if len(games) <= i {
+				games = append(games, models.StatGame{GameID: fmt.Sprintf("g%d", i+1), Stats: map[string]float64{}})
+			}
+			games[i].Stats[column] = v
+		}
+	}
+	return games
+}
+
+func leg(player, market, side string, line float64, components ...string) Leg {
+	return Leg{SimulationLeg: models.SimulationLeg{Player: player, Market: market, Side: side, Line: line}, Components: components}
+}
+
+func alternating(low, high float64, n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = low
+		if i%2 == 1 {
+			values[i] = high
+		}
+	}
+	return values
+}
+
+func TestSimulateIsReproducible(t *testing.T) {
+	logs := map[string][]models.StatGame{
+		"A": gameLog(map[string][]float64{"points": {18, 25, 31, 22, 27}, "assists": {4, 7, 9, 5, 6}}),
+	}
+	legs := []Leg{leg("A", "player_points_assists", "over", 30.5, "points", "assists")}
+
+	first, err := Simulate(logs, legs, 5000, 42)
+	require.NoError(t, err)
+	second, err := Simulate(logs, legs, 5000, 42)
+	require.NoError(t, err)
+	other, err := Simulate(logs, legs, 5000, 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first.Legs[0].Over, other.Legs[0].Over)
+	assert.Equal(t, uint64(42), first.Seed)
+	assert.Equal(t, 5000, first.Iterations)
+
+	r := first.Legs[0]
+	assert.InDelta(t, 30.8, r.Mean, 1e-9)
+	assert.Equal(t, 5, r.Games)
+	assert.InDelta(t, 1, r.Over+r.Under+r.Push, 1e-9)
+	assert.Equal(t, r.Over, r.Win)
+	assert.InDelta(t, 0.5, r.Over, 0.1)
+	assert.Equal(t, r.Win, first.Win)
+}
+
+func TestSimulateCorrelatesTeammates(t *testing.T) {
+	logs := map[string][]models.StatGame{
+		"A": gameLog(map[string][]float64{"points": alternating(10, 20, 20)}),
+		"B": gameLog(map[string][]float64{"points": alternating(5, 15, 20)}),
+		"C": gameLog(map[string][]float64{"points": alternating(15, 5, 20)}),
+	}
+
+	together, err := Simulate(logs, []Leg{
+		leg("A", "player_points", "over", 15.5, "points"),
+		leg("B", "player_points", "over", 10.5, "points"),
+	}, 20000, 1)
+	require.NoError(t, err)
+	// B scores when A does, so both overs hit together far more than independent legs would
+	assert.Greater(t, together.Win, together.IndependentWin+0.1)
+
+	opposed, err := Simulate(logs, []Leg{
+		leg("A", "player_points", "over", 15.5, "points"),
+		leg("C", "player_points", "over", 10.5, "points"),
+	}, 20000, 1)
+	require.NoError(t, err)
+	// C scores when A doesn't
+	assert.Less(t, opposed.Win, opposed.IndependentWin-0.1)
+	assert.InDelta(t, 1, opposed.Win+opposed.Push+opposed.Loss, 1e-9)
+}
+
+func TestSimulateFloorsAndPushes(t *testing.T) {
+	logs := map[string][]models.StatGame{
+		"A": gameLog(map[string][]float64{"receptions": {0, 1, 0, 1, 0, 2}}),
+		"B": gameLog(map[string][]float64{"rushingYards": {-4, 12, 3, 25, 8, 0}}),
+	}
+	result, err := Simulate(logs, []Leg{
+		leg("A", "player_receptions", "under", 1, "receptions"),
+		leg("B", "player_rush_yds", "over", -10, "rushingYards"),
+	}, 5000, 3)
+	require.NoError(t, err)
+
+	// whole-number draws land on the line, and never below zero or B's worst game
+	assert.Greater(t, result.Legs[0].Push, 0.0)
+	assert.Equal(t, 1.0, result.Legs[1].Win)
+	assert.Greater(t, result.Push, 0.0)
+}
+
+func TestSimulateErrors(t *testing.T) {
+	_, err := Simulate(nil, nil, 100, 1)
+	assert.Error(t, err)
+
+	logs := map[string][]models.StatGame{"A": gameLog(map[string][]float64{"points": {20}})}
+	_, err = Simulate(logs, []Leg{leg("A", "player_points", "over", 19.5, "points")}, 100, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,277 @@
+// Package stream detects changes between successive prop odds and scoreboard reads and fans them
+// out to subscribers, so many clients share one upstream poll. Odds changes are kept in a replay
+// buffer so reconnecting clients can resume where they left off, or learn that they can't.
+package stream
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sports_api/internal/identity"
+	"sports_api/internal/models"
+)
+
+const (
+	// replaySize is how many recent changes are kept for clients resuming with Last-Event-ID
+	replaySize = 1000
+	// subscriberBuffer is how many changes a slow subscriber may fall behind before it's dropped
+	subscriberBuffer = 256
+)
+
+// OddsSource returns the latest prop odds snapshot for a sport
+type OddsSource func(sport string) ([]models.Odds, error)
+
+// OddsFilter selects changes by sport, player, market and sportsbook. An empty list matches
+// everything; players match in any spelling identity.Canonical folds together.
+type OddsFilter struct {
+	Sports  []string
+	Players []string
+	Markets []string
+	Books   []string
+}
+
+// Match reports whether a change passes the filter
+func (f OddsFilter) Match(change models.OddsChange) bool {
+	return matchAny(f.Sports, change.Sport, strings.EqualFold) &&
+		matchAny(f.Players, change.Player, func(a, b string) bool { return identity.Canonical(a) == identity.Canonical(b) }) &&
+		matchAny(f.Markets, change.Market, strings.EqualFold) &&
+		matchAny(f.Books, change.Sportbook, strings.EqualFold)
+}
+
+func matchAny(values []string, s string, eq func(a, b string) bool) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if eq(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+type oddsKey struct {
+	sport, player, market, book string
+}
+
+type subscriber struct {
+	filter OddsFilter
+	ch     chan models.OddsChange
+}
+
+// OddsHub polls odds snapshots, diffs them and broadcasts the changes. Polling starts with the
+// first subscriber; the first snapshot of each sport is a baseline and produces no changes.
+type OddsHub struct {
+	source   OddsSource
+	sports   []string
+	interval time.Duration
+
+	start sync.Once
+
+	// epoch distinguishes this run's event IDs from an earlier process's, whose changes are gone
+	epoch string
+
+	mu          sync.Mutex
+	snapshots   map[string]map[oddsKey]models.Odds
+	nextID      int64
+	replay      []models.OddsChange
+	subscribers map[*subscriber]struct{}
+}
+
+// NewOddsHub creates a hub polling source for each sport every interval. An interval of 0
+// disables background polling and leaves calling Poll to the caller.
+func NewOddsHub(source OddsSource, sports []string, interval time.Duration) *OddsHub {
+	return &OddsHub{
+		source:      source,
+		sports:      sports,
+		interval:    interval,
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		snapshots:   make(map[string]map[oddsKey]models.Odds),
+		nextID:      1,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// EventID is the ID a client resumes from after change: the hub's epoch and the change's ID, so
+// IDs from an earlier run of the process are never mistaken for this run's
+func (h *OddsHub) EventID(change models.OddsChange) string {
+	return h.eventID(change.ID)
+}
+
+func (h *OddsHub) eventID(id int64) string {
+	return h.epoch + "-" + strconv.FormatInt(id, 10)
+}
+
+// Subscribe registers a subscriber and returns the buffered changes after lastEventID that match
+// the filter, a channel of new changes, and a cancel func to unsubscribe. The channel is closed
+// if the subscriber falls too far behind; the client should reconnect with its last event ID.
+// An empty lastEventID replays nothing. When lastEventID can't be served, because it is from an
+// earlier run, older than the replay buffer or malformed, nothing is replayed and resetID is the
+// ID of the latest change: the client has missed changes and should reload the current odds.
+func (h *OddsHub) Subscribe(filter OddsFilter, lastEventID string) (replay []models.OddsChange, resetID string, changes <-chan models.OddsChange, cancel func()) {
+	if h.interval > 0 {
+		h.start.Do(func() { go h.run() })
+	}
+
+	sub := &subscriber{filter: filter, ch: make(chan models.OddsChange, subscriberBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	replay = []models.OddsChange{}
+	if lastEventID != "" {
+		lastID, ok := h.parseEventID(lastEventID)
+		// the buffer holds IDs first..nextID-1, so resuming from first-1 onwards misses nothing
+		first := h.nextID - int64(len(h.replay))
+		if !ok || lastID < first-1 || lastID >= h.nextID {
+			resetID = h.eventID(h.nextID - 1)
+		} else {
+			for _, change := range h.replay {
+				if change.ID > lastID && filter.Match(change) {
+					replay = append(replay, change)
+				}
+			}
+		}
+	}
+	h.subscribers[sub] = struct{}{}
+
+	cancel = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(sub)
+	}
+	return replay, resetID, sub.ch, cancel
+}
+
+// parseEventID returns the change ID in an event ID from this hub's epoch
+func (h *OddsHub) parseEventID(eventID string) (int64, bool) {
+	epoch, id, ok := strings.Cut(eventID, "-")
+	if !ok || epoch != h.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// drop removes a subscriber and closes its channel. Callers must hold h.mu.
+func (h *OddsHub) drop(sub *subscriber) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+func (h *OddsHub) run() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		h.Poll(time.Now())
+		<-ticker.C
+	}
+}
+
+// Poll fetches a snapshot for every sport and broadcasts the changes since the previous one.
+// A sport whose fetch fails keeps its previous snapshot.
+func (h *OddsHub) Poll(now time.Time) {
+	for _, sport := range h.sports {
+		odds, err := h.source(sport)
+		if err != nil {
+			log.Printf("odds stream: failed to fetch %s odds: %v", sport, err)
+			continue
+		}
+
+		current := make(map[oddsKey]models.Odds, len(odds))
+		for _, odd := range odds {
+			current[oddsKey{sport, odd.Name, odd.Market, odd.Sportbook}] = odd
+		}
+
+		h.mu.Lock()
+		previous, ok := h.snapshots[sport]
+		h.snapshots[sport] = current
+		if ok {
+			h.publish(diffOdds(sport, previous, current, now))
+		}
+		h.mu.Unlock()
+	}
+}
+
+// publish assigns IDs to changes, records them for replay and sends them to matching
+// subscribers. Callers must hold h.mu.
+func (h *OddsHub) publish(changes []models.OddsChange) {
+	for _, change := range changes {
+		change.ID = h.nextID
+		h.nextID++
+
+		h.replay = append(h.replay, change)
+		if len(h.replay) > replaySize {
+			h.replay = h.replay[len(h.replay)-replaySize:]
+		}
+
+		for sub := range h.subscribers {
+			if !sub.filter.Match(change) {
+				continue
+			}
+			select {
+			case sub.ch <- change:
+			default:
+				h.drop(sub)
+			}
+		}
+	}
+}
+
+// diffOdds lists the lines that opened, moved or closed between two snapshots of a sport,
+// ordered by player, market and sportsbook
+func diffOdds(sport string, previous, current map[oddsKey]models.Odds, now time.Time) []models.OddsChange {
+	var changes []models.OddsChange
+	for key, cur := range current {
+		prev, ok := previous[key]
+		switch {
+		case !ok:
+			changes = append(changes, oddsChange("open", sport, models.Odds{}, cur, now))
+		case prev.Line != cur.Line || prev.Over != cur.Over || prev.Under != cur.Under:
+			changes = append(changes, oddsChange("move", sport, prev, cur, now))
+		}
+	}
+	for key, prev := range previous {
+		if _, ok := current[key]; !ok {
+			changes = append(changes, oddsChange("close", sport, prev, models.Odds{Name: prev.Name, Market: prev.Market, Sportbook: prev.Sportbook}, now))
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Player != b.Player {
+			return a.Player < b.Player
+		}
+		if a.Market != b.Market {
+			return a.Market < b.Market
+		}
+		return a.Sportbook < b.Sportbook
+	})
+	return changes
+}
+
+func oddsChange(kind, sport string, prev, cur models.Odds, now time.Time) models.OddsChange {
+	return models.OddsChange{
+		Type:          kind,
+		Sport:         sport,
+		Player:        cur.Name,
+		Market:        cur.Market,
+		Sportbook:     cur.Sportbook,
+		Line:          cur.Line,
+		Over:          cur.Over,
+		Under:         cur.Under,
+		PreviousLine:  prev.Line,
+		PreviousOver:  prev.Over,
+		PreviousUnder: prev.Under,
+		DetectedAt:    now,
+	}
+}
@@ -0,0 +1,137 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSource map[string][]models.Odds
+
+func (f fakeSource) fetch(sport string) ([]models.Odds, error) {
+	return f[sport], nil
+}
+
+func TestDiffOdds(t *testing.T) {
+	now := time.Date(2025, 1, 15, 18, 0, 0, 0, time.UTC)
+	key := func(o models.Odds) oddsKey { return oddsKey{"nba", o.Name, o.Market, o.Sportbook} }
+	steady := models.Odds{Name: "Jalen Brunson", Market: "points", Sportbook: "FanDuel", Line: 26.5, Over: -110, Under: -110}
+	before := models.Odds{Name: "Jalen Brunson", Market: "assists", Sportbook: "FanDuel", Line: 7.5, Over: -115, Under: -105}
+	after := before
+	after.Line, after.Over, after.Under = 8.5, 100, -120
+	pulled := models.Odds{Name: "Josh Hart", Market: "rebounds", Sportbook: "BetMGM", Line: 9.5, Over: -110, Under: -110}
+	opened := models.Odds{Name: "Mikal Bridges", Market: "points", Sportbook: "DraftKings", Line: 17.5, Over: -105, Under: -115}
+
+	previous := map[oddsKey]models.Odds{key(steady): steady, key(before): before, key(pulled): pulled}
+	current := map[oddsKey]models.Odds{key(steady): steady, key(after): after, key(opened): opened}
+
+	changes := diffOdds("nba", previous, current, now)
+	assert.Len(t, changes, 3)
+
+	assert.Equal(t, models.OddsChange{
+		Type: "move", Sport: "nba", Player: "Jalen Brunson", Market: "assists", Sportbook: "FanDuel",
+		Line: 8.5, Over: 100, Under: -120, PreviousLine: 7.5, PreviousOver: -115, PreviousUnder: -105,
+		DetectedAt: now,
+	}, changes[0])
+	assert.Equal(t, "close", changes[1].Type)
+	assert.Equal(t, "Josh Hart", changes[1].Player)
+	assert.Equal(t, float32(0), changes[1].Line)
+	assert.Equal(t, float32(9.5), changes[1].PreviousLine)
+	assert.Equal(t, "open", changes[2].Type)
+	assert.Equal(t, "Mikal Bridges", changes[2].Player)
+}
+
+func TestOddsHubSubscribeAndReplay(t *testing.T) {
+	source := fakeSource{
+		"nba": {{Name: "Jalen Brunson", Market: "points", Sportbook: "FanDuel", Line: 26.5}},
+		"nfl": {{Name: "Patrick Mahomes", Market: "passing yards", Sportbook: "DraftKings", Line: 265.5}},
+	}
+	hub := NewOddsHub(source.fetch, []string{"nba", "nfl"}, 0)
+	now := time.Now()
+
+	// the first poll is a baseline
+	hub.Poll(now)
+	replay, resetID, changes, cancel := hub.Subscribe(OddsFilter{Sports: []string{"NBA"}}, "")
+	defer cancel()
+	assert.Empty(t, replay)
+	assert.Empty(t, resetID)
+
+	source["nba"] = []models.Odds{{Name: "Jalen Brunson", Market: "points", Sportbook: "FanDuel", Line: 27.5}}
+	source["nfl"] = []models.Odds{{Name: "Patrick Mahomes", Market: "passing yards", Sportbook: "DraftKings", Line: 270.5}}
+	hub.Poll(now)
+
+	change := <-changes
+	assert.Equal(t, "nba", change.Sport)
+	assert.Equal(t, float32(27.5), change.Line)
+	assert.Empty(t, changes, "nfl change should be filtered out")
+
+	// a client resuming after the nba change gets the matching changes it missed
+	replay, resetID, _, cancelResume := hub.Subscribe(OddsFilter{Players: []string{"patrick mahomes"}}, hub.EventID(change))
+	defer cancelResume()
+	assert.Empty(t, resetID)
+	assert.Len(t, replay, 1)
+	assert.Equal(t, "Patrick Mahomes", replay[0].Player)
+}
+
+func TestOddsHubResetsUnservableEventIDs(t *testing.T) {
+	source := fakeSource{"nba": nil}
+	hub := NewOddsHub(source.fetch, []string{"nba"}, 0)
+	hub.Poll(time.Now())
+
+	var first, last models.OddsChange
+	for i := 0; i <= replaySize; i++ {
+		source["nba"] = []models.Odds{{Name: "Jalen Brunson", Market: "points", Sportbook: "FanDuel", Line: float32(i)}}
+		hub.Poll(time.Now())
+		if i == 0 {
+			first = hub.replay[0]
+		}
+	}
+	last = hub.replay[len(hub.replay)-1]
+
+	// the oldest buffered change can still be resumed from the one before it
+	replay, resetID, _, cancel := hub.Subscribe(OddsFilter{}, hub.EventID(first))
+	cancel()
+	assert.Empty(t, resetID)
+	assert.Len(t, replay, replaySize)
+
+	// another run numbers its changes the same way but must not be mistaken for this one
+	restarted := NewOddsHub(source.fetch, []string{"nba"}, 0)
+
+	tests := map[string]string{
+		"from another run":  restarted.EventID(first),
+		"older than buffer": hub.eventID(first.ID - 1),
+		"ahead of latest":   hub.eventID(last.ID + 1),
+		"malformed":         "42",
+	}
+	for name, lastEventID := range tests {
+		t.Run(name, func(t *testing.T) {
+			replay, resetID, _, cancel := hub.Subscribe(OddsFilter{}, lastEventID)
+			defer cancel()
+			assert.Empty(t, replay)
+			assert.Equal(t, hub.EventID(last), resetID)
+		})
+	}
+}
+
+func TestOddsHubDropsSlowSubscriber(t *testing.T) {
+	source := fakeSource{"nba": nil}
+	hub := NewOddsHub(source.fetch, []string{"nba"}, 0)
+	hub.Poll(time.Now())
+
+	_, _, changes, cancel := hub.Subscribe(OddsFilter{}, "")
+	defer cancel()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		source["nba"] = []models.Odds{{Name: "Jalen Brunson", Market: "points", Sportbook: "FanDuel", Line: float32(i)}}
+		hub.Poll(time.Now())
+	}
+
+	received := 0
+	for range changes {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+}
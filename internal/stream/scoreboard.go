@@ -0,0 +1,225 @@
+package stream
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"sports_api/internal/models"
+)
+
+// ScoreboardSource returns the current scoreboard
+type ScoreboardSource func() ([]models.Game, error)
+
+// ScoreboardSubscription receives updates for a set of games, or for every game except any
+// unsubscribed from when it is in all-games mode. An empty set means no games. Games can be added
+// and removed while the subscription is live.
+type ScoreboardSubscription struct {
+	Updates <-chan models.ScoreboardUpdate
+
+	ch      chan models.ScoreboardUpdate
+	mu      sync.Mutex
+	all     bool
+	gameIDs map[string]bool // the games followed, or in all-games mode the games left out
+}
+
+// Subscribe adds games to the subscription
+func (s *ScoreboardSubscription) Subscribe(gameIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range gameIDs {
+		if s.all {
+			delete(s.gameIDs, id)
+		} else {
+			s.gameIDs[id] = true
+		}
+	}
+}
+
+// Unsubscribe removes games from the subscription. Removing every game leaves a subscription to
+// no games.
+func (s *ScoreboardSubscription) Unsubscribe(gameIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range gameIDs {
+		if s.all {
+			s.gameIDs[id] = true
+		} else {
+			delete(s.gameIDs, id)
+		}
+	}
+}
+
+// SubscribeAll switches the subscription to every game, including games added to the scoreboard later
+func (s *ScoreboardSubscription) SubscribeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.all = true
+	clear(s.gameIDs)
+}
+
+// UnsubscribeAll switches the subscription to no games
+func (s *ScoreboardSubscription) UnsubscribeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.all = false
+	clear(s.gameIDs)
+}
+
+// Wants reports whether the subscription covers a game
+func (s *ScoreboardSubscription) Wants(gameID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.all != s.gameIDs[gameID]
+}
+
+// ScoreboardHub polls the scoreboard once for all subscribers and broadcasts score, status and
+// period changes. Polling starts with the first subscriber.
+type ScoreboardHub struct {
+	source   ScoreboardSource
+	interval time.Duration
+
+	start sync.Once
+
+	mu          sync.Mutex
+	games       []models.Game
+	subscribers map[*ScoreboardSubscription]struct{}
+}
+
+// NewScoreboardHub creates a hub polling source every interval. An interval of 0 disables
+// background polling and leaves calling Poll to the caller.
+func NewScoreboardHub(source ScoreboardSource, interval time.Duration) *ScoreboardHub {
+	return &ScoreboardHub{
+		source:      source,
+		interval:    interval,
+		subscribers: make(map[*ScoreboardSubscription]struct{}),
+	}
+}
+
+// Subscribe registers a subscription to gameIDs and returns it along with the current state of
+// those games and a cancel func to unsubscribe. Updates is closed if the subscriber falls more than
+// subscriberBuffer updates behind.
+func (h *ScoreboardHub) Subscribe(gameIDs ...string) (*ScoreboardSubscription, []models.Game, func()) {
+	return h.subscribe(func(sub *ScoreboardSubscription) { sub.Subscribe(gameIDs...) })
+}
+
+// SubscribeAll is Subscribe for every game, including games added to the scoreboard later
+func (h *ScoreboardHub) SubscribeAll() (*ScoreboardSubscription, []models.Game, func()) {
+	return h.subscribe(func(sub *ScoreboardSubscription) { sub.SubscribeAll() })
+}
+
+func (h *ScoreboardHub) subscribe(init func(sub *ScoreboardSubscription)) (*ScoreboardSubscription, []models.Game, func()) {
+	if h.interval > 0 {
+		h.start.Do(func() { go h.run() })
+	}
+
+	ch := make(chan models.ScoreboardUpdate, subscriberBuffer)
+	sub := &ScoreboardSubscription{Updates: ch, ch: ch, gameIDs: make(map[string]bool)}
+	init(sub)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.subscribers[sub] = struct{}{}
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(sub)
+	}
+	return sub, h.snapshot(sub), cancel
+}
+
+// Snapshot returns the current state of the games a subscription covers
+func (h *ScoreboardHub) Snapshot(sub *ScoreboardSubscription) []models.Game {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.snapshot(sub)
+}
+
+// snapshot filters the last polled games for sub. Callers must hold h.mu.
+func (h *ScoreboardHub) snapshot(sub *ScoreboardSubscription) []models.Game {
+	games := []models.Game{}
+	for _, game := range h.games {
+		if sub.Wants(game.GameID) {
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+// drop removes a subscription and closes its channel. Callers must hold h.mu.
+func (h *ScoreboardHub) drop(sub *ScoreboardSubscription) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+func (h *ScoreboardHub) run() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		h.Poll()
+		<-ticker.C
+	}
+}
+
+// Poll reads the scoreboard and sends each changed game to the subscriptions covering it.
+// A failed read keeps the previous scoreboard.
+func (h *ScoreboardHub) Poll() {
+	games, err := h.source()
+	if err != nil {
+		log.Printf("scoreboard stream: failed to fetch scoreboard: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	updates := diffScoreboard(h.games, games)
+	h.games = games
+	for _, update := range updates {
+		for sub := range h.subscribers {
+			if !sub.Wants(update.Game.GameID) {
+				continue
+			}
+			select {
+			case sub.ch <- update:
+			default:
+				h.drop(sub)
+			}
+		}
+	}
+}
+
+// diffScoreboard lists the games that are new or whose score, status or period changed, in the
+// order of the current scoreboard. The game clock alone doesn't count as a change.
+func diffScoreboard(previous, current []models.Game) []models.ScoreboardUpdate {
+	byID := make(map[string]models.Game, len(previous))
+	for _, game := range previous {
+		byID[game.GameID] = game
+	}
+
+	var updates []models.ScoreboardUpdate
+	for _, game := range current {
+		prev, ok := byID[game.GameID]
+		var changes []string
+		if !ok {
+			changes = append(changes, "new")
+		} else {
+			if prev.HomeScore != game.HomeScore || prev.AwayScore != game.AwayScore {
+				changes = append(changes, "score")
+			}
+			if prev.StatusCode != game.StatusCode || prev.StatusText != game.StatusText {
+				changes = append(changes, "status")
+			}
+			if prev.Period != game.Period {
+				changes = append(changes, "period")
+			}
+		}
+		if len(changes) > 0 {
+			updates = append(updates, models.ScoreboardUpdate{Type: "update", Changes: changes, Game: game})
+		}
+	}
+	return updates
+}
@@ -0,0 +1,91 @@
+package stream
+
+import (
+	"testing"
+
+	"sports_api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffScoreboard(t *testing.T) {
+	previous := []models.Game{
+		{GameID: "1", StatusCode: 2, Period: 2, GameClock: "5:00", HomeScore: 50, AwayScore: 48},
+		{GameID: "2", StatusCode: 1},
+		{GameID: "3", StatusCode: 2, Period: 4, GameClock: "0:30", HomeScore: 101, AwayScore: 99},
+	}
+	current := []models.Game{
+		{GameID: "1", StatusCode: 2, Period: 2, GameClock: "4:12", HomeScore: 50, AwayScore: 48},
+		{GameID: "2", StatusCode: 2, Period: 1, GameClock: "12:00"},
+		{GameID: "3", StatusCode: 3, Period: 4, HomeScore: 103, AwayScore: 99},
+		{GameID: "4", StatusCode: 1},
+	}
+
+	updates := diffScoreboard(previous, current)
+	assert.Len(t, updates, 3)
+	assert.Equal(t, "2", updates[0].Game.GameID)
+	assert.Equal(t, []string{"status", "period"}, updates[0].Changes)
+	assert.Equal(t, "3", updates[1].Game.GameID)
+	assert.Equal(t, []string{"score", "status"}, updates[1].Changes)
+	assert.Equal(t, "4", updates[2].Game.GameID)
+	assert.Equal(t, []string{"new"}, updates[2].Changes)
+}
+
+func TestScoreboardHubSubscriptions(t *testing.T) {
+	games := []models.Game{{GameID: "1"}, {GameID: "2"}}
+	hub := NewScoreboardHub(func() ([]models.Game, error) { return games, nil }, 0)
+	hub.Poll()
+
+	sub, snapshot, cancel := hub.Subscribe("2")
+	defer cancel()
+	assert.Equal(t, []models.Game{{GameID: "2"}}, snapshot)
+
+	games = []models.Game{{GameID: "1", HomeScore: 2}, {GameID: "2", HomeScore: 3}}
+	hub.Poll()
+	update := <-sub.Updates
+	assert.Equal(t, "2", update.Game.GameID)
+	assert.Empty(t, sub.Updates)
+
+	// unsubscribing from every game leaves no games
+	sub.Unsubscribe("2")
+	assert.Empty(t, hub.Snapshot(sub))
+	games = []models.Game{{GameID: "1", HomeScore: 4}, {GameID: "2", HomeScore: 4}}
+	hub.Poll()
+	assert.Empty(t, sub.Updates)
+
+	// all-games mode covers every game but those unsubscribed from
+	sub.SubscribeAll()
+	assert.Len(t, hub.Snapshot(sub), 2)
+	sub.Unsubscribe("1")
+	assert.Equal(t, []models.Game{{GameID: "2", HomeScore: 4}}, hub.Snapshot(sub))
+	games = []models.Game{{GameID: "1", HomeScore: 6}, {GameID: "2", HomeScore: 4}, {GameID: "3"}}
+	hub.Poll()
+	update = <-sub.Updates
+	assert.Equal(t, "3", update.Game.GameID)
+	assert.Empty(t, sub.Updates)
+
+	sub.Subscribe("1")
+	assert.Len(t, hub.Snapshot(sub), 3)
+	sub.UnsubscribeAll()
+	assert.Empty(t, hub.Snapshot(sub))
+}
+
+func TestScoreboardHubDropsSlowSubscriber(t *testing.T) {
+	score := 0
+	hub := NewScoreboardHub(func() ([]models.Game, error) {
+		score++
+		return []models.Game{{GameID: "1", HomeScore: score}}, nil
+	}, 0)
+
+	sub, _, cancel := hub.SubscribeAll()
+	defer cancel()
+	for i := 0; i <= subscriberBuffer; i++ {
+		hub.Poll()
+	}
+
+	received := 0
+	for range sub.Updates {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+}